@@ -1,9 +1,12 @@
-package main
+package arch
 
 import (
 	"fmt"
 	"os"
 	"runtime"
+
+	"github.com/mir-yks/LinuxCommandAnalog/internal/applet"
+	"github.com/mir-yks/LinuxCommandAnalog/internal/getopt"
 )
 
 type Config struct {
@@ -38,28 +41,15 @@ func printVersion() {
 	fmt.Println("Язык программирования: Golang")
 }
 
-// parseArgs разбирает аргументы командной строки в стиле ls/zip
+// parseArgs разбирает аргументы командной строки
 func parseArgs() *Config {
 	config := &Config{}
-	
-	for i := 1; i < len(os.Args); i++ {
-		arg := os.Args[i]
-		switch arg {
-		case "-h", "--help":
-			config.Help = true
-			continue
-		case "-v":
-			config.Verbose = true
-			continue
-		case "--version":
-			config.Version = true
-			continue
-		default:
-			if len(arg) > 1 && arg[0] == '-' {
-				panic(fmt.Sprintf("arch: неизвестная опция '%s'. Используйте -h для справки", arg))
-			}
-		}
-	}
+
+	opts := getopt.New("arch")
+	opts.Bool(&config.Help, 'h', "help")
+	opts.Bool(&config.Verbose, 'v', "verbose")
+	opts.Bool(&config.Version, 0, "version")
+	opts.Parse(os.Args[1:])
 
 	return config
 }
@@ -77,7 +67,12 @@ func printArchitecture(verbose bool) {
 	}
 }
 
-func main() {
+func init() {
+	applet.Register("arch", Main)
+}
+
+// Main запускает утилиту arch
+func Main() {
 	defer func() {
 		if r := recover(); r != nil {
 			fmt.Fprintf(os.Stderr, "arch: %v\n", r)
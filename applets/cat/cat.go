@@ -0,0 +1,312 @@
+package cat
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/mir-yks/LinuxCommandAnalog/internal/applet"
+	"github.com/mir-yks/LinuxCommandAnalog/internal/getopt"
+	"github.com/mir-yks/LinuxCommandAnalog/internal/input"
+)
+
+type Config struct {
+	Help         bool
+	Version      bool
+	NumNonEmpty  bool // -b: нумеровать непустые строки; главнее -n
+	NumAll       bool // -n: нумеровать все строки
+	ShowEnds     bool // -E: $ в конце строк
+	ShowTabs     bool // -T: табуляция как ^I
+	ShowNonPrint bool // -v: управляющие символы как ^X и M-X
+	SqueezeBlank bool // -s: несколько пустых строк подряд выводить одной
+	Filenames    []string
+}
+
+const ver = "1.0.0"
+
+func init() {
+	applet.Register("cat", Main)
+}
+
+// Main запускает утилиту cat
+func Main() {
+	defer func() {
+		if r := recover(); r != nil {
+			fmt.Fprintf(os.Stderr, "Ошибка: %v\n", r)
+			os.Exit(1)
+		}
+	}()
+
+	config := parseArgs()
+
+	if config.Help {
+		printHelp()
+		return
+	}
+
+	if config.Version {
+		printVersion()
+		return
+	}
+
+	ok, err := executeCat(config)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Ошибка: %v\n", err)
+		os.Exit(1)
+	}
+	if !ok {
+		os.Exit(1)
+	}
+}
+
+// parseArgs разбирает аргументы командной строки
+func parseArgs() *Config {
+	config := &Config{}
+
+	// Составные ключи GNU: -A = -vET, -e = -vE, -t = -vT
+	set := func(flags ...*bool) func(string) error {
+		return func(string) error {
+			for _, flag := range flags {
+				*flag = true
+			}
+			return nil
+		}
+	}
+
+	opts := getopt.New("cat")
+	opts.Bool(&config.Help, 'h', "help")
+	opts.Bool(&config.Version, 0, "version")
+	opts.Func('A', "show-all", getopt.NoArgument, set(&config.ShowNonPrint, &config.ShowEnds, &config.ShowTabs))
+	opts.Func('e', "", getopt.NoArgument, set(&config.ShowNonPrint, &config.ShowEnds))
+	opts.Func('t', "", getopt.NoArgument, set(&config.ShowNonPrint, &config.ShowTabs))
+	opts.Bool(&config.NumNonEmpty, 'b', "number-nonblank")
+	opts.Bool(&config.NumAll, 'n', "number")
+	opts.Bool(&config.ShowEnds, 'E', "show-ends")
+	opts.Bool(&config.ShowTabs, 'T', "show-tabs")
+	opts.Bool(&config.ShowNonPrint, 'v', "show-nonprinting")
+	opts.Bool(&config.SqueezeBlank, 's', "squeeze-blank")
+	// -u (без буферизации) принимается для совместимости: вывод и так
+	// сбрасывается, как только вход перестает поставлять данные
+	opts.Func('u', "", getopt.NoArgument, func(string) error { return nil })
+	config.Filenames = input.Operands(opts.Parse(os.Args[1:]))
+
+	return config
+}
+
+// printHelp выводит справку
+func printHelp() {
+	fmt.Println("cat - объединяет файлы и выводит их на стандартный вывод")
+	fmt.Println()
+	fmt.Println("Использование: cat [ОПЦИЯ]... [ФАЙЛ]...")
+	fmt.Println()
+	fmt.Println("Если ФАЙЛ не задан или задан как -, читается стандартный ввод. Без опций")
+	fmt.Println("файлы копируются байт в байт, в том числе двоичные.")
+	fmt.Println()
+	fmt.Println("Опции:")
+	fmt.Println("  -A, --show-all           эквивалентно -vET")
+	fmt.Println("  -b, --number-nonblank    нумеровать непустые строки (отменяет -n)")
+	fmt.Println("  -e                       эквивалентно -vE")
+	fmt.Println("  -E, --show-ends          выводить $ в конце каждой строки")
+	fmt.Println("  -n, --number             нумеровать все строки")
+	fmt.Println("  -s, --squeeze-blank      выводить несколько пустых строк подряд одной")
+	fmt.Println("  -t                       эквивалентно -vT")
+	fmt.Println("  -T, --show-tabs          выводить табуляцию как ^I")
+	fmt.Println("  -u                       игнорируется")
+	fmt.Println("  -v, --show-nonprinting   выводить управляющие символы как ^X, а байты")
+	fmt.Println("                           больше 127 - как M-X (кроме табуляции и")
+	fmt.Println("                           перевода строки)")
+	fmt.Println("  -h, --help               показать эту справку")
+	fmt.Println("      --version            показать информацию о версии")
+	fmt.Println()
+	fmt.Println("Нумерация строк продолжается от файла к файлу.")
+	fmt.Println()
+	fmt.Println("Примеры:")
+	fmt.Println("  cat file.txt             # Вывод содержимого файла")
+	fmt.Println("  cat -n file.txt          # Нумеровать все строки")
+	fmt.Println("  cat -b file.txt          # Нумеровать непустые строки")
+	fmt.Println("  cat -A file.txt          # Показать табуляцию, концы строк и CR (^M)")
+	fmt.Println("  cat -s log.txt           # Сжать серии пустых строк")
+	fmt.Println("  cat file1.txt file2.txt  # Объединить несколько файлов")
+	fmt.Println("  ls | cat -n              # Нумеровать строки из канала")
+}
+
+// printVersion выводит информацию о версии
+func printVersion() {
+	fmt.Println("cat версия", ver)
+	fmt.Println("Разработано в рамках учебного проекта")
+	fmt.Println("Язык программирования: Golang")
+}
+
+// formatting сообщает, что вывод отличается от входа и файл нельзя копировать как есть
+func (config *Config) formatting() bool {
+	return config.NumNonEmpty || config.NumAll || config.ShowEnds || config.ShowTabs ||
+		config.ShowNonPrint || config.SqueezeBlank
+}
+
+// catState - состояние вывода, общее для всех файлов: нумерация и сжатие
+// пустых строк продолжаются в следующем файле, как в GNU cat
+type catState struct {
+	line    int  // номер последней пронумерованной строки
+	atStart bool // следующий байт начинает строку
+	blank   int  // сколько пустых строк подряд выведено
+	cr      bool // -E: кусок строки закончился на \r, его вывод отложен
+}
+
+// executeCat выполняет основную логику команды cat. Ошибка чтения одного
+// файла не прерывает вывод остальных; тогда ok = false.
+func executeCat(config *Config) (ok bool, err error) {
+	out := bufio.NewWriterSize(os.Stdout, 64*1024)
+	state := &catState{atStart: true}
+	failed := false
+
+	for _, filename := range config.Filenames {
+		if err := readFile(filename, config, state, out); err != nil {
+			out.Flush()
+			fmt.Fprintf(os.Stderr, "cat: %v\n", err)
+			failed = true
+		}
+	}
+	if state.cr {
+		out.WriteByte('\r')
+	}
+	if err := out.Flush(); err != nil {
+		return false, err
+	}
+	return !failed, nil
+}
+
+// readFile выводит файл: без опций - копированием, иначе построчно
+func readFile(fn string, config *Config, state *catState, out *bufio.Writer) error {
+	f, err := input.Open(fn)
+	if err != nil {
+		return input.OpenError(fn, err)
+	}
+	defer f.Close()
+
+	if !config.formatting() {
+		if err := out.Flush(); err != nil {
+			return err
+		}
+		if _, err := io.Copy(os.Stdout, f); err != nil {
+			return input.ReadError(fn, err)
+		}
+		return nil
+	}
+
+	r := bufio.NewReaderSize(f, 64*1024)
+	for {
+		// Вывод сбрасывается, когда вход ждет данных: cat -n с терминала
+		// сразу показывает введенную строку
+		if r.Buffered() == 0 {
+			if err := out.Flush(); err != nil {
+				return err
+			}
+		}
+		// ReadSlice возвращает строку кусками размером с буфер, поэтому
+		// длина строки не ограничена
+		chunk, err := r.ReadSlice('\n')
+		if len(chunk) > 0 {
+			writeChunk(chunk, config, state, out)
+		}
+		if err == bufio.ErrBufferFull {
+			continue
+		}
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return input.ReadError(fn, err)
+		}
+	}
+}
+
+// writeChunk выводит часть строки; перевод строки, если есть, стоит в конце
+func writeChunk(chunk []byte, config *Config, state *catState, out *bufio.Writer) {
+	complete := chunk[len(chunk)-1] == '\n'
+	if complete {
+		chunk = chunk[:len(chunk)-1]
+	}
+
+	if state.atStart {
+		empty := complete && len(chunk) == 0
+		if empty {
+			state.blank++
+			if config.SqueezeBlank && state.blank > 1 {
+				return
+			}
+		} else {
+			state.blank = 0
+		}
+		if config.NumNonEmpty && !empty || config.NumAll && !config.NumNonEmpty {
+			state.line++
+			fmt.Fprintf(out, "%6d\t", state.line)
+		}
+	}
+
+	// С -E, как в GNU cat, \r перед концом строки выводится как ^M, чтобы
+	// строки из Windows было видно. \r в конце куска может оказаться перед
+	// переводом строки в следующем куске, поэтому его вывод откладывается.
+	if state.cr {
+		state.cr = false
+		if !complete || len(chunk) > 0 {
+			out.WriteByte('\r')
+		} else {
+			out.WriteString("^M")
+		}
+	}
+	crlf := false
+	if config.ShowEnds && !config.ShowNonPrint && len(chunk) > 0 && chunk[len(chunk)-1] == '\r' {
+		chunk = chunk[:len(chunk)-1]
+		crlf, state.cr = complete, !complete
+	}
+
+	if config.ShowNonPrint || config.ShowTabs {
+		writeVisible(chunk, config, out)
+	} else {
+		out.Write(chunk)
+	}
+
+	state.atStart = complete
+	if complete {
+		if crlf {
+			out.WriteString("^M")
+		}
+		if config.ShowEnds {
+			out.WriteByte('$')
+		}
+		out.WriteByte('\n')
+	}
+}
+
+// writeVisible выводит байты в нотации GNU cat: ^X для управляющих
+// символов, ^? для DEL и M- перед байтами больше 127
+func writeVisible(data []byte, config *Config, out *bufio.Writer) {
+	for _, c := range data {
+		if c == '\t' {
+			if config.ShowTabs {
+				out.WriteString("^I")
+			} else {
+				out.WriteByte(c)
+			}
+			continue
+		}
+		if !config.ShowNonPrint {
+			out.WriteByte(c)
+			continue
+		}
+		if c >= 128 {
+			out.WriteString("M-")
+			c -= 128
+		}
+		switch {
+		case c < 32:
+			out.WriteByte('^')
+			out.WriteByte(c + 64)
+		case c == 127:
+			out.WriteString("^?")
+		default:
+			out.WriteByte(c)
+		}
+	}
+}
@@ -1,11 +1,13 @@
-package main
+package cd
 
 import (
-	"flag"
 	"fmt"
 	"os"
 	"os/exec"
 	"path/filepath"
+
+	"github.com/mir-yks/LinuxCommandAnalog/internal/applet"
+	"github.com/mir-yks/LinuxCommandAnalog/internal/getopt"
 )
 
 type Config struct {
@@ -22,39 +24,32 @@ const ver = "1.0.0"
 
 func parseArgs() *Config {
 	config := &Config{}
-	
-	// Создаем флаги для всех опций
-	help := flag.Bool("h", false, "показать справку и выйти")
-	helpLong := flag.Bool("help", false, "показать справку и выйти")
-	version := flag.Bool("version", false, "показать информацию о версии и выйти")
-	physical := flag.Bool("P", false, "физическая смена директории")
-	logical := flag.Bool("L", false, "логическая смена директории")
-	verbose := flag.Bool("v", false, "выводить подробную информацию")
-	
-	flag.Parse()
-	
-	config.Help = *help || *helpLong
-	config.Version = *version
-	config.Physical = *physical
-	config.Logical = *logical
-	config.Verbose = *verbose
-	
+
+	opts := getopt.New("cd")
+	opts.Bool(&config.Help, 'h', "help")
+	opts.Bool(&config.Version, 0, "version")
+	opts.Bool(&config.Physical, 'P', "")
+	opts.Bool(&config.Logical, 'L', "")
+	opts.Bool(&config.Verbose, 'v', "verbose")
+	args := opts.Parse(os.Args[1:])
+
+	if config.Help || config.Version {
+		return config
+	}
 	// Проверяем позиционные аргументы
-	switch flag.NArg() {
+	switch len(args) {
 	case 0:
-		// Путь не указан
+		opts.Failf("не указан путь")
 	case 1:
-		if flag.Arg(0) == "-" {
+		if args[0] == "-" {
 			config.Previous = true
 		} else {
-			config.Path = flag.Arg(0)
+			config.Path = args[0]
 		}
 	default:
-		fmt.Fprintf(os.Stderr, "cd: лишний операнд '%s'\n", flag.Arg(1))
-		fmt.Fprintln(os.Stderr, "Используйте 'cd -h' для получения справки")
-		os.Exit(1)
+		opts.Failf("лишний операнд '%s'", args[1])
 	}
-	
+
 	return config
 }
 
@@ -74,11 +69,6 @@ func executeCd(config *Config) {
 		}
 		finalPath = filepath.Dir(current)
 	} else {
-		if config.Path == "" {
-			fmt.Fprintln(os.Stderr, "cd: не указан путь")
-			fmt.Fprintln(os.Stderr, "Используйте 'cd -h' для получения справки")
-			os.Exit(1)
-		}
 		finalPath = resolvePath(config.Path)
 	}
 
@@ -139,7 +129,12 @@ func printVersion() {
 	fmt.Println("Язык программирования: Golang")
 }
 
-func main() {
+func init() {
+	applet.Register("cd", Main)
+}
+
+// Main запускает утилиту cd
+func Main() {
 	config := parseArgs()
 
 	if config.Help {
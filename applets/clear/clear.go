@@ -1,9 +1,11 @@
-package main
+package clear
 
 import (
-	"flag"
 	"fmt"
 	"os"
+
+	"github.com/mir-yks/LinuxCommandAnalog/internal/applet"
+	"github.com/mir-yks/LinuxCommandAnalog/internal/getopt"
 )
 
 type ClearOptions struct {
@@ -23,24 +25,19 @@ const (
 
 func parseFlags() ClearOptions {
 	var options ClearOptions
-	
-	flag.BoolVar(&options.Help, "h", false, "показать справку и выйти")
-	flag.BoolVar(&options.Help, "help", false, "показать справку и выйти")
-	flag.BoolVar(&options.Version, "v", false, "показать версию и выйти")
-	flag.BoolVar(&options.Version, "version", false, "показать версию и выйти")
-	flag.BoolVar(&options.CursesVer, "V", false, "показать версию curses и выйти")
-	flag.BoolVar(&options.Force, "f", false, "принудительная очистка (игнорировать проверки)")
-	flag.BoolVar(&options.Force, "force", false, "принудительная очистка (игнорировать проверки)")
-	flag.BoolVar(&options.NoScroll, "x", false, "не очищать буфер прокрутки")
-	
-	flag.Parse()
-	
-	if flag.NArg() > 0 {
-		fmt.Fprintf(os.Stderr, "clear: лишний операнд '%s'\n", flag.Arg(0))
-		fmt.Fprintln(os.Stderr, "Используйте 'clear -h' для получения справки")
-		os.Exit(1)
+
+	opts := getopt.New("clear")
+	opts.Bool(&options.Help, 'h', "help")
+	opts.Bool(&options.Version, 'v', "version")
+	opts.Bool(&options.CursesVer, 'V', "")
+	opts.Bool(&options.Force, 'f', "force")
+	opts.Bool(&options.NoScroll, 'x', "")
+	args := opts.Parse(os.Args[1:])
+
+	if len(args) > 0 {
+		opts.Failf("лишний операнд '%s'", args[0])
 	}
-	
+
 	return options
 }
 
@@ -111,7 +108,12 @@ func clearScreen(options ClearOptions) error {
 	return nil
 }
 
-func main() {
+func init() {
+	applet.Register("clear", Main)
+}
+
+// Main запускает утилиту clear
+func Main() {
 	options := parseFlags()
 	
 	if options.Help {
@@ -1,12 +1,14 @@
-package main
+package cp
 
 import (
-	"flag"
 	"fmt"
 	"io"
 	"os"
 	"path/filepath"
 	"strings"
+
+	"github.com/mir-yks/LinuxCommandAnalog/internal/applet"
+	"github.com/mir-yks/LinuxCommandAnalog/internal/getopt"
 )
 
 type Config struct {
@@ -23,7 +25,12 @@ type Config struct {
 
 const version = "1.0.0"
 
-func main() {
+func init() {
+	applet.Register("cp", Main)
+}
+
+// Main запускает утилиту cp
+func Main() {
 	config := parseArgs()
 	
 	if config.Help {
@@ -38,12 +45,6 @@ func main() {
 		return
 	}
 
-	if len(config.Src) == 0 || config.Dst == "" {
-		fmt.Fprintln(os.Stderr, "cp: укажите источник и назначение")
-		printHelp()
-		os.Exit(1)
-	}
-
 	if err := copyPaths(config); err != nil {
 		fmt.Fprintf(os.Stderr, "cp: %v\n", err)
 		os.Exit(1)
@@ -56,28 +57,31 @@ func main() {
 
 func parseArgs() *Config {
 	config := &Config{}
-	
-	flag.BoolVar(&config.Help, "h", false, "показать справку и выйти")
-	flag.BoolVar(&config.Help, "help", false, "показать справку и выйти")
-	flag.BoolVar(&config.Version, "version", false, "показать версию и выйти")
-	flag.BoolVar(&config.Recursive, "r", false, "копировать рекурсивно (для каталогов)")
-	flag.BoolVar(&config.Recursive, "R", false, "копировать рекурсивно (для каталогов)")
-	flag.BoolVar(&config.Interactive, "i", false, "интерактивный режим (запрашивать перед перезаписью)")
-	flag.BoolVar(&config.Verbose, "v", false, "подробный режим")
-	flag.BoolVar(&config.Preserve, "p", false, "сохранять время изменения и права доступа")
-	flag.BoolVar(&config.Update, "u", false, "копировать только новые файлы")
-	
-	flag.Parse()
-	
-	// Правильная обработка позиционных аргументов
-	args := flag.Args()
-	if len(args) == 0 {
+
+	opts := getopt.New("cp")
+	opts.Bool(&config.Help, 'h', "help")
+	opts.Bool(&config.Version, 0, "version")
+	opts.Bool(&config.Recursive, 'r', "recursive")
+	opts.Bool(&config.Recursive, 'R', "")
+	opts.Bool(&config.Interactive, 'i', "interactive")
+	opts.Bool(&config.Verbose, 'v', "verbose")
+	opts.Bool(&config.Preserve, 'p', "")
+	opts.Bool(&config.Update, 'u', "update")
+	args := opts.Parse(os.Args[1:])
+
+	if config.Help || config.Version {
 		return config
 	}
-	
+	switch len(args) {
+	case 0:
+		opts.Failf("пропущен операнд, задающий файл")
+	case 1:
+		opts.Failf("после '%s' пропущен операнд, задающий целевой файл", args[0])
+	}
+
 	config.Dst = args[len(args)-1]
 	config.Src = args[:len(args)-1]
-	
+
 	return config
 }
 
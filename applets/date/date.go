@@ -1,10 +1,13 @@
-package main
+package date
 
 import (
 	"bufio"
 	"fmt"
 	"os"
 	"time"
+
+	"github.com/mir-yks/LinuxCommandAnalog/internal/applet"
+	"github.com/mir-yks/LinuxCommandAnalog/internal/getopt"
 )
 
 type Config struct {
@@ -12,12 +15,18 @@ type Config struct {
 	Version   bool
 	Date      string   
 	File      string   
+	Reference bool     
 	Filenames []string 
 }
 
 const ver = "1.0.0"
 
-func main() {
+func init() {
+	applet.Register("date", Main)
+}
+
+// Main запускает утилиту date
+func Main() {
 	defer func() {
 		if r := recover(); r != nil {
 			fmt.Fprintf(os.Stderr, "Ошибка: %v\n", r)
@@ -44,59 +53,21 @@ func main() {
 	}
 }
 
-// parseArgs разбирает аргументы командной строки вручную
+// parseArgs разбирает аргументы командной строки
 func parseArgs() *Config {
 	config := &Config{}
-	i := 1
-
-	for i < len(os.Args) {
-		arg := os.Args[i]
-
-		switch arg {
-		case "-h":
-			config.Help = true
-			i++
-			continue
-		case "-v", "--version":
-			config.Version = true
-			i++
-			continue
-		case "-d":
-			i++
-			if i >= len(os.Args) {
-				panic("date: опция требует операнд -- 'd'")
-			}
-			config.Date = os.Args[i]
-			i++
-			continue
-		case "-f", "-r":
-			i++
-			if i >= len(os.Args) {
-				panic(fmt.Sprintf("date: опция '%s' требует операнд -- '%s'", arg, arg[1:]))
-			}
-			config.File = os.Args[i]
-			i++
-			continue
-		default:
-			if len(arg) > 1 && arg[0] == '-' {
-				for _, ch := range arg[1:] {
-					switch ch {
-					case 'h':
-						config.Help = true
-					case 'v':
-						config.Version = true
-					case 'd', 'f', 'r':
-						panic(fmt.Sprintf("date: опция '%c' требует операнд", ch))
-					default:
-						panic(fmt.Sprintf("date: неверный ключ — '%s'", arg))
-					}
-				}
-			} else {
-				config.Filenames = append(config.Filenames, arg)
-			}
-			i++
-		}
-	}
+
+	opts := getopt.New("date")
+	opts.Bool(&config.Help, 'h', "help")
+	opts.Bool(&config.Version, 'v', "version")
+	opts.String(&config.Date, 'd', "date")
+	opts.String(&config.File, 'f', "file")
+	opts.Func('r', "reference", getopt.RequiredArgument, func(value string) error {
+		config.File = value
+		config.Reference = true
+		return nil
+	})
+	config.Filenames = opts.Parse(os.Args[1:])
 
 	return config
 }
@@ -167,7 +138,7 @@ func processFile(filePath string, config *Config) error {
 	}
 	defer file.Close()
 
-	if os.Args[1] == "-r" {
+	if config.Reference {
 		info, err := file.Stat()
 		if err != nil {
 			return fmt.Errorf("не удалось получить информацию о файле: %v", err)
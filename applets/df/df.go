@@ -0,0 +1,371 @@
+package df
+
+import (
+	"fmt"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/mir-yks/LinuxCommandAnalog/internal/applet"
+	"github.com/mir-yks/LinuxCommandAnalog/internal/display"
+	"github.com/mir-yks/LinuxCommandAnalog/internal/getopt"
+	"github.com/mir-yks/LinuxCommandAnalog/internal/human"
+	"github.com/mir-yks/LinuxCommandAnalog/internal/input"
+)
+
+type Config struct {
+	Help         bool
+	Version      bool
+	All          bool     // -a: показывать виртуальные ФС и повторные монтирования
+	BlockSize    uint64   // единица вывода размеров в байтах
+	BlockLabel   string   // подпись единицы в заголовке: 1K, 1M
+	Human        int      // 1024 для -h, 1000 для -H, 0 - числа в BlockSize
+	Inodes       bool     // -i: inode вместо блоков
+	Types        []string // -t: показывать только эти типы
+	ExcludeTypes []string // -x: не показывать эти типы
+	Total        bool     // --total: итоговая строка
+	Direct       bool     // --direct: вместо точки монтирования выводить ФАЙЛ
+	Files        []string
+}
+
+const ver = "1.0.0"
+
+// printHelp выводит справку по использованию утилиты df
+func printHelp() {
+	fmt.Println("df - отчет об использовании дискового пространства")
+	fmt.Println()
+	fmt.Println("Использование: df [ОПЦИЯ]... [ФАЙЛ]...")
+	fmt.Println()
+	fmt.Println("Показывает размер, занятое и доступное место файловых систем. Если указан")
+	fmt.Println("ФАЙЛ, выводится файловая система, на которой он находится. Доступное место")
+	fmt.Println("не включает блоки, зарезервированные для root.")
+	fmt.Println()
+	fmt.Println("Опции:")
+	fmt.Println("  -a, --all              Показать все ФС, включая виртуальные (proc, sysfs)")
+	fmt.Println("  -B, --block-size=РАЗМЕР")
+	fmt.Println("                         Размеры в единицах РАЗМЕР (1K, 4096, 1M, 1G, 1MB)")
+	fmt.Println("  -k                     То же, что --block-size=1K (по умолчанию)")
+	fmt.Println("  -h, --human-readable   Размеры в виде 1K, 234M, 2G (степени 1024)")
+	fmt.Println("  -H, --si               То же, но степени 1000")
+	fmt.Println("  -i, --inodes           Показать использование inode вместо блоков")
+	fmt.Println("  -t, --type=ТИП         Показать только ФС типа ТИП")
+	fmt.Println("  -x, --exclude-type=ТИП Не показывать ФС типа ТИП")
+	fmt.Println("      --total            Вывести итоговую строку")
+	fmt.Println("      --direct           Выводить ФАЙЛ вместо точки монтирования")
+	fmt.Println("      --help             Показать эту справку")
+	fmt.Println("  -v, --version          Показать информацию о версии")
+	fmt.Println()
+	fmt.Println("Примеры:")
+	fmt.Println("  df -h                  # Все дисковые ФС в удобном виде")
+	fmt.Println("  df -h .                # ФС текущей директории")
+	fmt.Println("  df -i -t ext4          # Inode на ФС ext4")
+	fmt.Println("  df -x tmpfs --total    # Без tmpfs, с итогом")
+}
+
+// printVersion выводит информацию о версии программы
+func printVersion() {
+	fmt.Println("df версия", ver)
+	fmt.Println("Разработано в рамках учебного проекта")
+	fmt.Println("Язык программирования: Golang")
+}
+
+// parseArgs разбирает аргументы командной строки
+func parseArgs() *Config {
+	config := &Config{BlockSize: 1024, BlockLabel: "1K"}
+
+	opts := getopt.New("df")
+	opts.Bool(&config.Help, 0, "help")
+	opts.Bool(&config.Version, 'v', "version")
+	opts.Bool(&config.All, 'a', "all")
+	opts.Func('B', "block-size", getopt.RequiredArgument, func(value string) error {
+		size, label, err := parseSize(value)
+		if err != nil {
+			return err
+		}
+		config.BlockSize, config.BlockLabel, config.Human = size, label, 0
+		return nil
+	})
+	opts.Func('k', "", getopt.NoArgument, func(string) error {
+		config.BlockSize, config.BlockLabel, config.Human = 1024, "1K", 0
+		return nil
+	})
+	opts.Func('h', "human-readable", getopt.NoArgument, func(string) error {
+		config.Human = 1024
+		return nil
+	})
+	opts.Func('H', "si", getopt.NoArgument, func(string) error {
+		config.Human = 1000
+		return nil
+	})
+	opts.Bool(&config.Inodes, 'i', "inodes")
+	opts.Strings(&config.Types, 't', "type")
+	opts.Strings(&config.ExcludeTypes, 'x', "exclude-type")
+	opts.Bool(&config.Total, 0, "total")
+	opts.Bool(&config.Direct, 0, "direct")
+	config.Files = opts.Parse(os.Args[1:])
+
+	for _, t := range config.Types {
+		if contains(config.ExcludeTypes, t) {
+			opts.Failf("тип ФС '%s' одновременно выбран и исключен", t)
+		}
+	}
+	if config.Direct && len(config.Files) == 0 {
+		opts.Failf("для --direct нужно указать ФАЙЛ")
+	}
+
+	return config
+}
+
+// parseSize разбирает размер блока: число с необязательным суффиксом
+// K, M, G, T, P, E (степени 1024; KiB - то же) или KB, MB... (степени 1000).
+// Возвращает размер в байтах и подпись для заголовка.
+func parseSize(value string) (uint64, string, error) {
+	s := strings.ToUpper(value)
+	digits := strings.TrimRightFunc(s, func(r rune) bool { return r < '0' || r > '9' })
+	suffix := s[len(digits):]
+
+	num := uint64(1)
+	if digits != "" {
+		n, err := strconv.ParseUint(digits, 10, 64)
+		if err != nil || n == 0 {
+			return 0, "", fmt.Errorf("неверный размер блока '%s'", value)
+		}
+		num = n
+	}
+	if suffix == "" {
+		return num, digits, nil
+	}
+
+	base := uint64(1024)
+	unit := suffix[:1]
+	switch suffix[1:] {
+	case "", "IB":
+	case "B":
+		base = 1000
+	default:
+		return 0, "", fmt.Errorf("неверный размер блока '%s'", value)
+	}
+	power := strings.Index("KMGTPE", unit)
+	if power < 0 {
+		return 0, "", fmt.Errorf("неверный размер блока '%s'", value)
+	}
+
+	size := num
+	for i := 0; i <= power; i++ {
+		if size > math.MaxUint64/base {
+			return 0, "", fmt.Errorf("слишком большой размер блока '%s'", value)
+		}
+		size *= base
+	}
+	return size, strconv.FormatUint(num, 10) + suffix, nil
+}
+
+func contains(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
+
+// selectMounts выбирает файловые системы для вывода: указанные файлами
+// или все, прошедшие фильтры -t, -x и -a
+func selectMounts(config *Config, mounts []*mount) ([]*mount, []string, bool) {
+	ok := true
+	if len(config.Files) > 0 {
+		var selected []*mount
+		var names []string
+		for _, name := range config.Files {
+			m, err := findMount(mounts, name)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "df: '%s': %v\n", name, input.Describe(err))
+				ok = false
+				continue
+			}
+			if contains(config.ExcludeTypes, m.Type) ||
+				len(config.Types) > 0 && !contains(config.Types, m.Type) {
+				continue
+			}
+			selected = append(selected, m)
+			names = append(names, name)
+		}
+		return selected, names, ok
+	}
+
+	var selected []*mount
+	for _, m := range mounts {
+		if contains(config.ExcludeTypes, m.Type) {
+			continue
+		}
+		if len(config.Types) > 0 && !contains(config.Types, m.Type) {
+			continue
+		}
+		// Явно запрошенный -t тип показывается, даже если он виртуальный
+		if m.pseudo() && !config.All && !contains(config.Types, m.Type) {
+			continue
+		}
+		selected = append(selected, m)
+	}
+	if !config.All {
+		selected = dedup(selected)
+	}
+	return selected, nil, ok
+}
+
+// formatSize выводит байты в единицах -B или в виде -h/-H. Размеры
+// округляются вверх, как в GNU df.
+func (config *Config) formatSize(bytes uint64) string {
+	switch config.Human {
+	case 1024:
+		return human.Size(bytes)
+	case 1000:
+		return human.SizeSI(bytes)
+	}
+	return strconv.FormatUint((bytes+config.BlockSize-1)/config.BlockSize, 10)
+}
+
+// formatCount выводит число inode; с -h/-H оно тоже сокращается
+func (config *Config) formatCount(n uint64) string {
+	switch config.Human {
+	case 1024:
+		return human.Size(n)
+	case 1000:
+		return human.SizeSI(n)
+	}
+	return strconv.FormatUint(n, 10)
+}
+
+// percent считает процент использования с округлением вверх; "-", если
+// у файловой системы нет ни занятого, ни доступного места
+func percent(used, avail uint64) string {
+	if used+avail == 0 {
+		return "-"
+	}
+	return fmt.Sprintf("%.0f%%", math.Ceil(float64(used)*100/float64(used+avail)))
+}
+
+// row формирует строку таблицы для файловой системы
+func (config *Config) row(m *mount, dir string) []string {
+	if config.Inodes {
+		return []string{m.Source, m.Type, config.formatCount(m.Inodes),
+			config.formatCount(m.IUsed), config.formatCount(m.IFree),
+			percent(m.IUsed, m.IFree), dir}
+	}
+	return []string{m.Source, m.Type, config.formatSize(m.Total),
+		config.formatSize(m.Used), config.formatSize(m.Avail),
+		percent(m.Used, m.Avail), dir}
+}
+
+// header возвращает заголовки столбцов
+func (config *Config) header() []string {
+	last := "Смонтировано в"
+	if config.Direct {
+		last = "Файл"
+	}
+	if config.Inodes {
+		return []string{"Файл.система", "Тип", "Inode", "IИспольз", "IСвоб", "IИспольз%", last}
+	}
+	if config.Human != 0 {
+		return []string{"Файл.система", "Тип", "Размер", "Использовано", "Дост", "Использовано%", last}
+	}
+	return []string{"Файл.система", "Тип", config.BlockLabel + "-блоков", "Использовано", "Доступно", "Использовано%", last}
+}
+
+// printTable выравнивает столбцы: имена - по левому краю, числа - по правому
+func printTable(rows [][]string) {
+	widths := make([]int, len(rows[0]))
+	for _, row := range rows {
+		for i, cell := range row {
+			widths[i] = max(widths[i], display.String(cell))
+		}
+	}
+
+	var b strings.Builder
+	for _, row := range rows {
+		for i, cell := range row {
+			pad := strings.Repeat(" ", widths[i]-display.String(cell))
+			switch {
+			case i == len(row)-1:
+				b.WriteString(cell)
+			case i < 2:
+				b.WriteString(cell + pad + " ")
+			default:
+				b.WriteString(pad + cell + " ")
+			}
+		}
+		b.WriteString("\n")
+	}
+	fmt.Print(b.String())
+}
+
+// executeDf выполняет основную логику утилиты df
+func executeDf(config *Config) bool {
+	mounts, err := readMounts()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "df: %v\n", err)
+		return false
+	}
+
+	selected, names, ok := selectMounts(config, mounts)
+	if len(selected) == 0 {
+		if ok {
+			fmt.Fprintln(os.Stderr, "df: не найдено подходящих файловых систем")
+		}
+		return false
+	}
+
+	rows := [][]string{config.header()}
+	total := &mount{Source: "итого", Type: "-"}
+	for i, m := range selected {
+		dir := m.Dir
+		if config.Direct {
+			dir = names[i]
+		}
+		rows = append(rows, config.row(m, dir))
+
+		total.Total += m.Total
+		total.Used += m.Used
+		total.Avail += m.Avail
+		total.Inodes += m.Inodes
+		total.IUsed += m.IUsed
+		total.IFree += m.IFree
+	}
+	if config.Total {
+		rows = append(rows, config.row(total, "-"))
+	}
+
+	printTable(rows)
+	return ok
+}
+
+func init() {
+	applet.Register("df", Main)
+}
+
+// Main запускает утилиту df
+func Main() {
+	defer func() {
+		if r := recover(); r != nil {
+			fmt.Fprintf(os.Stderr, "df: %v\n", r)
+			os.Exit(1)
+		}
+	}()
+
+	config := parseArgs()
+
+	if config.Help {
+		printHelp()
+		return
+	}
+
+	if config.Version {
+		printVersion()
+		return
+	}
+
+	if !executeDf(config) {
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,168 @@
+package df
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+)
+
+// mount - смонтированная файловая система и ее статистика
+type mount struct {
+	Source string // устройство или источник: /dev/sda1, tmpfs
+	Dir    string // точка монтирования
+	Type   string
+
+	Dev    uint64 // устройство, которое stat сообщает для файлов этой ФС
+	Total  uint64 // байты
+	Used   uint64
+	Avail  uint64 // доступно обычному пользователю, без зарезервированных блоков
+	Inodes uint64
+	IUsed  uint64
+	IFree  uint64
+}
+
+// pseudoTypes - виртуальные файловые системы без данных на диске; они
+// скрываются без -a. tmpfs и devtmpfs занимают память и показываются.
+var pseudoTypes = map[string]bool{
+	"autofs": true, "binfmt_misc": true, "bpf": true, "cgroup": true,
+	"cgroup2": true, "configfs": true, "debugfs": true, "devpts": true,
+	"efivarfs": true, "fusectl": true, "hugetlbfs": true, "mqueue": true,
+	"nsfs": true, "proc": true, "pstore": true, "rpc_pipefs": true,
+	"securityfs": true, "selinuxfs": true, "sysfs": true, "tracefs": true,
+}
+
+// unescapeMount раскодирует восьмеричные последовательности /proc/mounts:
+// пробел в пути записывается как \040, табуляция - как \011
+func unescapeMount(s string) string {
+	if !strings.Contains(s, `\`) {
+		return s
+	}
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] == '\\' && i+3 < len(s) {
+			if n, err := strconv.ParseUint(s[i+1:i+4], 8, 8); err == nil {
+				b.WriteByte(byte(n))
+				i += 3
+				continue
+			}
+		}
+		b.WriteByte(s[i])
+	}
+	return b.String()
+}
+
+// readMounts читает /proc/mounts и получает statfs каждой файловой системы.
+// Недоступные точки монтирования пропускаются, как в GNU df.
+func readMounts() ([]*mount, error) {
+	data, err := os.ReadFile("/proc/mounts")
+	if err != nil {
+		return nil, fmt.Errorf("не удается прочитать /proc/mounts: %v", err)
+	}
+
+	var mounts []*mount
+	for _, line := range strings.Split(string(data), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 3 {
+			continue
+		}
+		m := &mount{
+			Source: unescapeMount(fields[0]),
+			Dir:    unescapeMount(fields[1]),
+			Type:   fields[2],
+		}
+		if err := m.stat(); err != nil {
+			continue
+		}
+		mounts = append(mounts, m)
+	}
+	return mounts, nil
+}
+
+// stat заполняет размеры из statfs и номер устройства из stat
+func (m *mount) stat() error {
+	var fs syscall.Statfs_t
+	if err := syscall.Statfs(m.Dir, &fs); err != nil {
+		return err
+	}
+	var st syscall.Stat_t
+	if err := syscall.Stat(m.Dir, &st); err != nil {
+		return err
+	}
+	m.Dev = uint64(st.Dev)
+
+	bsize := uint64(fs.Bsize)
+	m.Total = uint64(fs.Blocks) * bsize
+	m.Used = (uint64(fs.Blocks) - uint64(fs.Bfree)) * bsize
+	m.Avail = uint64(fs.Bavail) * bsize
+	m.Inodes = uint64(fs.Files)
+	m.IFree = uint64(fs.Ffree)
+	m.IUsed = m.Inodes - min(m.IFree, m.Inodes)
+	return nil
+}
+
+// pseudo сообщает, что файловая система виртуальная или не имеет блоков
+func (m *mount) pseudo() bool {
+	return pseudoTypes[m.Type] || m.Total == 0
+}
+
+// dedup оставляет по одной записи на устройство. Одна ФС может быть
+// смонтирована несколько раз (bind mount, повторный tmpfs); как и GNU df,
+// выбираем самую короткую точку монтирования.
+func dedup(mounts []*mount) []*mount {
+	index := map[uint64]int{}
+	var result []*mount
+	for _, m := range mounts {
+		i, seen := index[m.Dev]
+		if !seen {
+			index[m.Dev] = len(result)
+			result = append(result, m)
+			continue
+		}
+		if len(m.Dir) < len(result[i].Dir) {
+			result[i] = m
+		}
+	}
+	return result
+}
+
+// findMount возвращает файловую систему, содержащую файл: самую длинную
+// точку монтирования, которая является префиксом пути и находится на том
+// же устройстве
+func findMount(mounts []*mount, name string) (*mount, error) {
+	var st syscall.Stat_t
+	if err := syscall.Stat(name, &st); err != nil {
+		return nil, err
+	}
+	abs, err := filepath.Abs(name)
+	if err != nil {
+		return nil, err
+	}
+	if resolved, err := filepath.EvalSymlinks(abs); err == nil {
+		abs = resolved
+	}
+
+	var best *mount
+	for _, m := range mounts {
+		if m.Dev != uint64(st.Dev) || !hasPathPrefix(abs, m.Dir) {
+			continue
+		}
+		if best == nil || len(m.Dir) > len(best.Dir) {
+			best = m
+		}
+	}
+	if best == nil {
+		return nil, fmt.Errorf("файловая система не найдена")
+	}
+	return best, nil
+}
+
+// hasPathPrefix проверяет, что путь p находится внутри директории dir
+func hasPathPrefix(p, dir string) bool {
+	if dir == "/" || p == dir {
+		return true
+	}
+	return strings.HasPrefix(p, dir+"/")
+}
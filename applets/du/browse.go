@@ -0,0 +1,313 @@
+package du
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/mir-yks/LinuxCommandAnalog/internal/display"
+	"github.com/mir-yks/LinuxCommandAnalog/internal/human"
+	"github.com/mir-yks/LinuxCommandAnalog/internal/term"
+)
+
+// clearScreen переводит курсор в начало и очищает экран
+const clearScreen = "\033[H\033[2J"
+
+// chromeLines - строки вне списка: путь, итог директории и строка состояния
+const chromeLines = 3
+
+// barWidth - ширина полоски; она показывает размер относительно самого
+// большого элемента директории, как в ncdu
+const barWidth = 10
+
+// browser - интерактивный просмотр снимка du -i
+type browser struct {
+	snap     *snapshot
+	config   *Config
+	cur      *node // открытая директория
+	selected int
+	offset   int    // первая видимая строка списка
+	message  string // строка состояния до следующего нажатия клавиши
+	in       *bufio.Reader
+	out      *bufio.Writer
+}
+
+// isInteractive проверяет, что ввод и вывод подключены к терминалу
+func isInteractive() bool {
+	return term.IsTerminal(os.Stdin.Fd()) && term.IsTerminal(os.Stdout.Fd())
+}
+
+// browse показывает снимок и обрабатывает клавиши, пока пользователь не выйдет
+func browse(snap *snapshot, config *Config) error {
+	screen, err := term.OpenScreen()
+	if err != nil {
+		return err
+	}
+	defer screen.Close()
+
+	b := &browser{
+		snap:   snap,
+		config: config,
+		cur:    snap.Root,
+		in:     bufio.NewReader(os.Stdin),
+		out:    bufio.NewWriter(os.Stdout),
+	}
+	if snap.imported {
+		b.message = fmt.Sprintf("Снимок %s от %s: удаление и обновление недоступны",
+			snap.Host, snap.Time.Format("2006-01-02 15:04"))
+	}
+
+	defer b.out.Flush()
+
+	for {
+		b.render()
+		key := b.readKey()
+		b.message = ""
+		if !b.handle(key) {
+			return nil
+		}
+	}
+}
+
+// readKey читает клавишу; стрелки и другие последовательности ESC [
+// возвращаются словами: "up", "down", "pgup" и т.д.
+func (b *browser) readKey() string {
+	c, err := b.in.ReadByte()
+	if err != nil {
+		return "q"
+	}
+	switch c {
+	case '\r', '\n':
+		return "enter"
+	case 3, 4: // Ctrl+C, Ctrl+D
+		return "q"
+	case 127, 8:
+		return "left"
+	case 033:
+		// Вся последовательность приходит одним чтением; одиночный ESC игнорируется
+		if b.in.Buffered() == 0 {
+			return ""
+		}
+		c, _ = b.in.ReadByte()
+		if c != '[' && c != 'O' {
+			return ""
+		}
+		seq := ""
+		for b.in.Buffered() > 0 {
+			c, _ = b.in.ReadByte()
+			seq += string(c)
+			if c >= 0x40 && c <= 0x7e {
+				break
+			}
+		}
+		switch seq {
+		case "A":
+			return "up"
+		case "B":
+			return "down"
+		case "C":
+			return "right"
+		case "D":
+			return "left"
+		case "H", "1~":
+			return "home"
+		case "F", "4~":
+			return "end"
+		case "5~":
+			return "pgup"
+		case "6~":
+			return "pgdn"
+		}
+		return ""
+	}
+	return string(c)
+}
+
+// handle выполняет действие клавиши; false означает выход
+func (b *browser) handle(key string) bool {
+	children := b.cur.Children
+	page := term.ListHeight(chromeLines)
+
+	switch key {
+	case "q":
+		return false
+	case "up", "k":
+		b.selected--
+	case "down", "j":
+		b.selected++
+	case "pgup":
+		b.selected -= page
+	case "pgdn":
+		b.selected += page
+	case "home", "g":
+		b.selected = 0
+	case "end", "G":
+		b.selected = len(children) - 1
+	case "right", "l", "enter":
+		if b.selected < len(children) && children[b.selected].Dir {
+			b.cur = children[b.selected]
+			b.selected, b.offset = 0, 0
+		}
+	case "left", "h":
+		if b.cur.parent != nil {
+			from := b.cur
+			b.cur = b.cur.parent
+			b.offset = 0
+			for i, child := range b.cur.Children {
+				if child == from {
+					b.selected = i
+				}
+			}
+		}
+	case "d":
+		b.delete()
+	case "r":
+		b.rescan()
+	}
+
+	b.selected = max(0, min(b.selected, len(b.cur.Children)-1))
+	return true
+}
+
+// delete удаляет выбранный элемент после подтверждения
+func (b *browser) delete() {
+	if b.snap.imported {
+		b.message = "Удаление недоступно в снимке, загруженном из файла"
+		return
+	}
+	if b.selected >= len(b.cur.Children) {
+		return
+	}
+	target := b.cur.Children[b.selected]
+
+	b.message = fmt.Sprintf("Удалить %s (%s)? [y/N]", target.path(), human.Size(target.Size))
+	b.render()
+	if answer := b.readKey(); answer != "y" && answer != "Y" {
+		b.message = "Удаление отменено"
+		return
+	}
+
+	if err := os.RemoveAll(target.path()); err != nil {
+		b.message = fmt.Sprintf("Ошибка удаления: %v", err)
+		// Часть файлов могла удалиться: обновляем директорию с диска
+		b.rescan()
+		return
+	}
+
+	b.cur.Children = append(b.cur.Children[:b.selected], b.cur.Children[b.selected+1:]...)
+	b.cur.addSize(-int64(target.Size))
+	b.message = fmt.Sprintf("Удалено: %s", target.path())
+}
+
+// rescan заново сканирует открытую директорию и обновляет размеры предков
+func (b *browser) rescan() {
+	if b.snap.imported {
+		b.message = "Обновление недоступно в снимке, загруженном из файла"
+		return
+	}
+
+	fresh := scanTree(b.cur.path(), b.config)
+	if fresh == nil {
+		b.message = fmt.Sprintf("Не удалось прочитать %s", b.cur.path())
+		return
+	}
+	for _, child := range fresh.Children {
+		child.parent = b.cur
+	}
+	b.cur.Children = fresh.Children
+	b.cur.addSize(int64(fresh.Size) - int64(b.cur.Size))
+	if b.message == "" {
+		b.message = fmt.Sprintf("Обновлено: %s", b.cur.path())
+	}
+}
+
+// fit обрезает строку до ширины в ячейках и дополняет пробелами
+func fit(s string, width int) string {
+	if width <= 0 {
+		return ""
+	}
+	w := display.String(s)
+	if w <= width {
+		return s + strings.Repeat(" ", width-w)
+	}
+	var cut strings.Builder
+	used := 0
+	for _, r := range s {
+		rw := display.RuneWidth(r)
+		if used+rw > width-1 {
+			break
+		}
+		cut.WriteRune(r)
+		used += rw
+	}
+	return cut.String() + "~" + strings.Repeat(" ", width-used-1)
+}
+
+// render перерисовывает экран целиком
+func (b *browser) render() {
+	cols, _, ok := term.Size(os.Stdout.Fd())
+	if !ok {
+		cols = 80
+	}
+	height := term.ListHeight(chromeLines)
+	children := b.cur.Children
+
+	// Выбранная строка всегда на экране
+	if b.selected < b.offset {
+		b.offset = b.selected
+	}
+	if b.selected >= b.offset+height {
+		b.offset = b.selected - height + 1
+	}
+
+	out := b.out
+	out.WriteString(clearScreen)
+	out.WriteString(term.Reverse + fit(" du -i: "+b.cur.path(), cols) + term.Reset + "\r\n")
+	out.WriteString(fit(fmt.Sprintf(" Всего: %s, элементов: %d", human.Size(b.cur.Size), len(children)), cols) + "\r\n")
+
+	var largest uint64
+	for _, child := range children {
+		largest = max(largest, child.Size)
+	}
+
+	for row := 0; row < height; row++ {
+		i := b.offset + row
+		if i >= len(children) {
+			if len(children) == 0 && row == 0 {
+				out.WriteString(" (пусто)")
+			}
+			out.WriteString("\r\n")
+			continue
+		}
+		child := children[i]
+
+		percent := 0.0
+		if b.cur.Size > 0 {
+			percent = float64(child.Size) * 100 / float64(b.cur.Size)
+		}
+		filled := 0
+		if largest > 0 {
+			filled = int((child.Size*barWidth + largest/2) / largest)
+		}
+		name := child.Name
+		if child.Dir {
+			name += "/"
+		}
+		line := fmt.Sprintf(" %7s [%-*s] %5.1f%%  %s",
+			human.Size(child.Size), barWidth, strings.Repeat("#", filled), percent, name)
+
+		if i == b.selected {
+			out.WriteString(term.Reverse + fit(line, cols) + term.Reset + "\r\n")
+		} else {
+			out.WriteString(fit(line, cols) + "\r\n")
+		}
+	}
+
+	status := b.message
+	if status == "" {
+		status = "↑↓ выбор  → войти  ← назад  d удалить  r обновить  q выход"
+	}
+	out.WriteString(term.Reverse + fit(" "+status, cols) + term.Reset)
+	out.Flush()
+}
@@ -0,0 +1,344 @@
+package du
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path"
+	"strconv"
+	"strings"
+	"syscall"
+
+	"github.com/mir-yks/LinuxCommandAnalog/internal/applet"
+	"github.com/mir-yks/LinuxCommandAnalog/internal/getopt"
+	"github.com/mir-yks/LinuxCommandAnalog/internal/human"
+	"github.com/mir-yks/LinuxCommandAnalog/internal/walk"
+)
+
+type Config struct {
+	Help         bool
+	Version      bool
+	Summary      bool
+	All          bool
+	MaxDepth     int      // -d: выводить директории не глубже N; -1 - все
+	Human        bool     // -h: 1.5K, 20M
+	BlockSize    uint64   // единица вывода в байтах: 1024 (-k) или 1048576 (-m)
+	ApparentSize bool     // --apparent-size: размер данных вместо занятых блоков
+	Total        bool     // -c: итоговая строка
+	OneFS        bool     // -x: не учитывать другие файловые системы
+	Exclude      []string // --exclude: шаблоны пропускаемых файлов
+	Interactive  bool     // -i: интерактивный просмотр
+	Export       string   // --export: сохранить снимок в JSON
+	Import       string   // --import: просматривать снимок из JSON
+	Paths        []string
+}
+
+const ver = "1.0.0"
+
+// printHelp выводит справку по использованию утилиты du
+func printHelp() {
+	fmt.Println("du - оценка использования дискового пространства")
+	fmt.Println()
+	fmt.Println("Использование: du [ОПЦИЯ]... [ПУТЬ]...")
+	fmt.Println()
+	fmt.Println("Для каждой директории выводится занятое место на диске вместе с")
+	fmt.Println("содержимым, по умолчанию в килобайтах. Жесткие ссылки на один файл")
+	fmt.Println("учитываются один раз.")
+	fmt.Println()
+	fmt.Println("Опции:")
+	fmt.Println("  -a, --all              Показать размер каждого файла, а не только директорий")
+	fmt.Println("  -s, --summarize        Вывести только общий размер каждого ПУТИ")
+	fmt.Println("  -d, --max-depth=N      Выводить директории не глубже N уровней")
+	fmt.Println("  -c, --total            Вывести общий итог всех ПУТЕЙ")
+	fmt.Println("  -h, --human-readable   Размеры в виде 1K, 234M, 2G")
+	fmt.Println("  -k                     Размеры в килобайтах (по умолчанию)")
+	fmt.Println("  -m                     Размеры в мегабайтах")
+	fmt.Println("  -b, --bytes            Размеры в байтах (включает --apparent-size)")
+	fmt.Println("      --apparent-size    Размер данных файлов вместо занятых блоков")
+	fmt.Println("  -x, --one-file-system  Пропускать директории на других файловых системах")
+	fmt.Println("      --exclude=ШАБЛОН   Пропускать файлы, подходящие под ШАБЛОН")
+	fmt.Println()
+	fmt.Println("Интерактивный режим:")
+	fmt.Println("  -i, --interactive      Просмотр дерева по убыванию размера с переходом по")
+	fmt.Println("                         директориям, удалением (d) и обновлением (r)")
+	fmt.Println("      --export=ФАЙЛ      Сохранить результат сканирования в JSON (- для вывода)")
+	fmt.Println("      --import=ФАЙЛ      Просмотреть снимок, сохраненный --export;")
+	fmt.Println("                         вместе с --export - пересохранить его")
+	fmt.Println()
+	fmt.Println("      --help             Показать эту справку")
+	fmt.Println("  -v, --version          Показать информацию о версии")
+	fmt.Println()
+	fmt.Println("Примеры:")
+	fmt.Println("  du                     # Размеры директорий в текущей")
+	fmt.Println("  du -sh /tmp            # Общий размер /tmp")
+	fmt.Println("  du -h -d 1 /var        # Размеры поддиректорий /var")
+	fmt.Println("  du -ac --exclude='*.o' # Каждый файл, кроме .o, и итог")
+	fmt.Println("  du -i /var             # Найти, что занимает место")
+	fmt.Println("  du --export=scan.json / && du --import=scan.json")
+}
+
+// printVersion выводит информацию о версии программы
+func printVersion() {
+	fmt.Println("du версия", ver)
+	fmt.Println("Разработано в рамках учебного проекта")
+	fmt.Println("Язык программирования: Golang")
+}
+
+// parseArgs разбирает аргументы командной строки
+func parseArgs() *Config {
+	config := &Config{MaxDepth: -1, BlockSize: 1024}
+
+	opts := getopt.New("du")
+	opts.Bool(&config.Help, 0, "help")
+	opts.Bool(&config.Version, 'v', "version")
+	opts.Bool(&config.Summary, 's', "summarize")
+	opts.Bool(&config.All, 'a', "all")
+	opts.Func('d', "max-depth", getopt.RequiredArgument, func(value string) error {
+		depth, err := strconv.Atoi(value)
+		if err != nil || depth < 0 {
+			return fmt.Errorf("неверная глубина '%s'", value)
+		}
+		config.MaxDepth = depth
+		return nil
+	})
+	opts.Bool(&config.Human, 'h', "human-readable")
+	opts.Func('k', "", getopt.NoArgument, func(string) error {
+		config.BlockSize = 1024
+		config.Human = false
+		return nil
+	})
+	opts.Func('m', "", getopt.NoArgument, func(string) error {
+		config.BlockSize = 1024 * 1024
+		config.Human = false
+		return nil
+	})
+	opts.Func('b', "bytes", getopt.NoArgument, func(string) error {
+		config.BlockSize = 1
+		config.ApparentSize = true
+		config.Human = false
+		return nil
+	})
+	opts.Bool(&config.ApparentSize, 0, "apparent-size")
+	opts.Bool(&config.Total, 'c', "total")
+	opts.Bool(&config.OneFS, 'x', "one-file-system")
+	opts.Strings(&config.Exclude, 0, "exclude")
+	opts.Bool(&config.Interactive, 'i', "interactive")
+	opts.String(&config.Export, 0, "export")
+	opts.String(&config.Import, 0, "import")
+	config.Paths = opts.Parse(os.Args[1:])
+
+	if config.Summary {
+		if config.MaxDepth > 0 {
+			opts.Failf("нельзя одновременно выводить только итог и директории до глубины %d", config.MaxDepth)
+		}
+		if config.All {
+			opts.Failf("нельзя одновременно выводить только итог и все файлы")
+		}
+		config.MaxDepth = 0
+	}
+	if config.Import != "" {
+		// Снимок без других режимов просматривается; с --export его
+		// можно пересохранить без терминала
+		if config.Export == "" {
+			config.Interactive = true
+		}
+		if len(config.Paths) > 0 {
+			opts.Failf("при --import ПУТЬ не указывается")
+		}
+	}
+	if (config.Interactive || config.Export != "") && len(config.Paths) > 1 {
+		opts.Failf("интерактивный режим и --export принимают только один ПУТЬ")
+	}
+	if len(config.Paths) == 0 {
+		config.Paths = []string{"."}
+	}
+
+	return config
+}
+
+// fileID - устройство и inode; по ним узнаются жесткие ссылки на один файл
+type fileID struct {
+	dev, ino uint64
+}
+
+// counter подсчитывает занятое место при обходе
+type counter struct {
+	config  *Config
+	out     *bufio.Writer
+	totals  map[*walk.Entry]uint64 // накопленный размер содержимого директорий
+	skipped map[*walk.Entry]bool   // исключенные --exclude и -x и повторные ссылки
+	seen    map[fileID]bool        // уже учтенные файлы
+	hashAll bool                   // запоминать в seen все файлы, а не только жесткие ссылки
+	failed  bool
+}
+
+// excluded проверяет путь по шаблонам --exclude. Шаблон сравнивается
+// с именем файла и с каждым окончанием пути: "build/*.o" исключит
+// файлы .o в любой директории build.
+func excluded(p string, patterns []string) bool {
+	for _, pattern := range patterns {
+		for suffix := p; ; {
+			if matched, _ := path.Match(pattern, suffix); matched {
+				return true
+			}
+			slash := strings.IndexByte(suffix, '/')
+			if slash < 0 {
+				break
+			}
+			suffix = suffix[slash+1:]
+		}
+	}
+	return false
+}
+
+// enter решает, учитывать ли файл и спускаться ли в директорию. Повторные
+// жесткие ссылки пропускаются; при нескольких ПУТЯХ запоминаются все файлы,
+// чтобы директория внутри уже посчитанной не вошла в итог дважды.
+func (c *counter) enter(e *walk.Entry) bool {
+	skip := e.Depth > 0 && excluded(e.Path, c.config.Exclude)
+	if c.config.OneFS && e.Parent != nil && e.Dev != rootDev(e) {
+		skip = true
+	}
+	if !skip {
+		st, ok := e.Info.Sys().(*syscall.Stat_t)
+		if ok && (c.hashAll || (!e.Info.IsDir() && st.Nlink > 1)) {
+			id := fileID{e.Dev, e.Ino}
+			skip = c.seen[id]
+			c.seen[id] = true
+		}
+	}
+	if skip {
+		c.skipped[e] = true
+		return false
+	}
+	return true
+}
+
+func rootDev(e *walk.Entry) uint64 {
+	for e.Parent != nil {
+		e = e.Parent
+	}
+	return e.Dev
+}
+
+// size возвращает место, занятое самим файлом
+func (c *counter) size(e *walk.Entry) uint64 {
+	st, ok := e.Info.Sys().(*syscall.Stat_t)
+	if c.config.ApparentSize || !ok {
+		return uint64(e.Info.Size())
+	}
+	// Stat_t.Blocks всегда считается в 512-байтных блоках
+	return uint64(st.Blocks) * 512
+}
+
+// leave вызывается после всего содержимого файла: добавляет его размер
+// к директории и выводит строку
+func (c *counter) leave(e *walk.Entry) uint64 {
+	if c.skipped[e] {
+		delete(c.skipped, e)
+		return 0
+	}
+
+	total := c.size(e)
+	if e.Info.IsDir() {
+		total += c.totals[e]
+		delete(c.totals, e)
+	}
+	if e.Parent != nil {
+		c.totals[e.Parent] += total
+	}
+
+	depthOK := c.config.MaxDepth < 0 || e.Depth <= c.config.MaxDepth
+	if (e.Info.IsDir() || c.config.All || e.Depth == 0) && depthOK {
+		fmt.Fprintf(c.out, "%s\t%s\n", c.format(total), e.Path)
+	}
+	return total
+}
+
+// format переводит байты в единицы вывода, округляя вверх, как GNU du
+func (c *counter) format(bytes uint64) string {
+	if c.config.Human {
+		return human.Size(bytes)
+	}
+	return strconv.FormatUint((bytes+c.config.BlockSize-1)/c.config.BlockSize, 10)
+}
+
+// newCounter создает счетчик для одного запуска du
+func newCounter(config *Config) *counter {
+	return &counter{
+		config:  config,
+		out:     bufio.NewWriter(os.Stdout),
+		totals:  map[*walk.Entry]uint64{},
+		skipped: map[*walk.Entry]bool{},
+		seen:    map[fileID]bool{},
+		hashAll: len(config.Paths) > 1,
+	}
+}
+
+// executeDu выполняет основную логику утилиты du
+func executeDu(config *Config) bool {
+	c := newCounter(config)
+	defer c.out.Flush()
+
+	var grandTotal uint64
+	walk.Walk(config.Paths, walk.Options{
+		Sorted:   true,
+		SameFS:   config.OneFS,
+		MaxDepth: -1,
+		Enter:    c.enter,
+		Leave: func(e *walk.Entry) {
+			total := c.leave(e)
+			if e.Depth == 0 {
+				grandTotal += total
+			}
+		},
+		Error: func(path string, err error) {
+			c.out.Flush()
+			fmt.Fprintf(os.Stderr, "du: не удается прочитать '%s': %v\n", path, err)
+			c.failed = true
+		},
+	})
+
+	if config.Total {
+		fmt.Fprintf(c.out, "%s\ttotal\n", c.format(grandTotal))
+	}
+	return !c.failed
+}
+
+func init() {
+	applet.Register("du", Main)
+}
+
+// Main запускает утилиту du
+func Main() {
+	defer func() {
+		if r := recover(); r != nil {
+			fmt.Fprintf(os.Stderr, "du: %v\n", r)
+			os.Exit(1)
+		}
+	}()
+
+	config := parseArgs()
+
+	if config.Help {
+		printHelp()
+		return
+	}
+
+	if config.Version {
+		printVersion()
+		return
+	}
+
+	if config.Interactive || config.Export != "" {
+		if err := executeInteractive(config); err != nil {
+			fmt.Fprintf(os.Stderr, "du: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	if !executeDu(config) {
+		os.Exit(1)
+	}
+}
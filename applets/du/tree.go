@@ -0,0 +1,207 @@
+package du
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/mir-yks/LinuxCommandAnalog/internal/input"
+	"github.com/mir-yks/LinuxCommandAnalog/internal/walk"
+)
+
+// node - файл или директория в снимке, который просматривает du -i
+type node struct {
+	Name     string  `json:"name"`
+	Size     uint64  `json:"size"`
+	Dir      bool    `json:"dir,omitempty"`
+	Children []*node `json:"children,omitempty"`
+
+	parent *node
+}
+
+// snapshot - результат сканирования, который можно сохранить в JSON
+// и просмотреть на другой машине
+type snapshot struct {
+	Version  int       `json:"version"`
+	Host     string    `json:"host,omitempty"`
+	Time     time.Time `json:"time"`
+	Apparent bool      `json:"apparent_size"`
+	Root     *node     `json:"root"`
+
+	imported bool // снимок загружен из файла: удалять и пересканировать нельзя
+}
+
+const snapshotVersion = 1
+
+// path возвращает путь к файлу: у корня это путь сканирования
+func (n *node) path() string {
+	if n.parent == nil {
+		return n.Name
+	}
+	return filepath.Join(n.parent.path(), n.Name)
+}
+
+// sortBySize упорядочивает содержимое директорий по убыванию размера
+func (n *node) sortBySize() {
+	sort.SliceStable(n.Children, func(i, j int) bool {
+		a, b := n.Children[i], n.Children[j]
+		if a.Size != b.Size {
+			return a.Size > b.Size
+		}
+		return a.Name < b.Name
+	})
+	for _, child := range n.Children {
+		child.sortBySize()
+	}
+}
+
+// linkParents восстанавливает ссылки на родителей после загрузки JSON
+func (n *node) linkParents() {
+	for _, child := range n.Children {
+		child.parent = n
+		child.linkParents()
+	}
+}
+
+// addSize изменяет размер узла и всех его предков
+func (n *node) addSize(delta int64) {
+	for p := n; p != nil; p = p.parent {
+		p.Size = uint64(int64(p.Size) + delta)
+	}
+}
+
+// scanTree сканирует дерево так же, как обычный du (блоки, жесткие ссылки,
+// -x, --exclude), но сохраняет все файлы. Порядок обхода не важен, поэтому
+// используется быстрый неупорядоченный режим.
+func scanTree(root string, config *Config) *node {
+	c := newCounter(config)
+	c.hashAll = false
+	nodes := map[*walk.Entry]*node{}
+	var top *node
+
+	walk.Walk([]string{root}, walk.Options{
+		SameFS:   config.OneFS,
+		MaxDepth: -1,
+		Enter: func(e *walk.Entry) bool {
+			if !c.enter(e) {
+				return false
+			}
+			n := &node{Name: e.Info.Name(), Dir: e.Info.IsDir()}
+			if parent := nodes[e.Parent]; parent != nil {
+				n.parent = parent
+				parent.Children = append(parent.Children, n)
+			} else {
+				n.Name = root
+				top = n
+			}
+			nodes[e] = n
+			return true
+		},
+		Leave: func(e *walk.Entry) {
+			n := nodes[e]
+			if n == nil {
+				delete(c.skipped, e)
+				return
+			}
+			delete(nodes, e)
+			n.Size += c.size(e)
+			if n.parent != nil {
+				n.parent.Size += n.Size
+			}
+		},
+		Error: func(path string, err error) {
+			// Ошибки не прерывают просмотр: недоступные директории
+			// просто показываются с тем размером, что удалось посчитать
+		},
+	})
+
+	if top != nil {
+		top.sortBySize()
+	}
+	return top
+}
+
+// exportSnapshot сохраняет снимок в JSON; "-" - стандартный вывод
+func exportSnapshot(s *snapshot, name string) error {
+	if name == "-" {
+		return json.NewEncoder(os.Stdout).Encode(s)
+	}
+
+	f, err := os.Create(name)
+	if err != nil {
+		return err
+	}
+	if err := json.NewEncoder(f).Encode(s); err != nil {
+		f.Close()
+		return err
+	}
+	// Ошибка записи на диск может проявиться только при закрытии
+	return f.Close()
+}
+
+// importSnapshot загружает снимок, сохраненный --export
+func importSnapshot(name string) (*snapshot, error) {
+	data, err := os.ReadFile(name)
+	if err != nil {
+		return nil, err
+	}
+	s := &snapshot{}
+	if err := json.Unmarshal(data, s); err != nil {
+		return nil, fmt.Errorf("'%s' не является снимком du: %v", name, err)
+	}
+	if s.Version != snapshotVersion || s.Root == nil {
+		return nil, fmt.Errorf("'%s': неподдерживаемая версия снимка %d", name, s.Version)
+	}
+	s.Root.linkParents()
+	s.Root.sortBySize()
+	s.imported = true
+	return s, nil
+}
+
+// newSnapshot сканирует путь и оформляет результат как снимок
+func newSnapshot(root string, config *Config) (*snapshot, error) {
+	if _, err := os.Lstat(root); err != nil {
+		return nil, fmt.Errorf("не удается прочитать '%s': %v", root, input.Describe(err))
+	}
+	host, _ := os.Hostname()
+	return &snapshot{
+		Version:  snapshotVersion,
+		Host:     host,
+		Time:     time.Now(),
+		Apparent: config.ApparentSize,
+		Root:     scanTree(root, config),
+	}, nil
+}
+
+// executeInteractive сканирует дерево или загружает снимок, сохраняет его
+// при --export и открывает просмотр при -i
+func executeInteractive(config *Config) error {
+	// Проверяем терминал до сканирования, которое может быть долгим
+	if config.Interactive && !isInteractive() {
+		return fmt.Errorf("интерактивный режим требует терминал")
+	}
+
+	var snap *snapshot
+	var err error
+	if config.Import != "" {
+		snap, err = importSnapshot(config.Import)
+	} else {
+		snap, err = newSnapshot(config.Paths[0], config)
+	}
+	if err != nil {
+		return err
+	}
+
+	if config.Export != "" {
+		if err := exportSnapshot(snap, config.Export); err != nil {
+			return err
+		}
+	}
+	if !config.Interactive {
+		return nil
+	}
+	return browse(snap, config)
+}
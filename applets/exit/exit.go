@@ -1,27 +1,33 @@
-package main
+package exit
 
 import (
-	"flag"
 	"fmt"
 	"os"
 	"syscall"
+
+	"github.com/mir-yks/LinuxCommandAnalog/internal/applet"
+	"github.com/mir-yks/LinuxCommandAnalog/internal/getopt"
 )
 
-func main() {
-	// Определяем флаги
-	help := flag.Bool("h", false, "Показать помощь и выйти")
-	helpLong := flag.Bool("help", false, "Показать помощь и выйти")
-	version := flag.Bool("v", false, "Показать версию и выйти")
-	versionLong := flag.Bool("version", false, "Показать версию и выйти")
-	
-	flag.Parse()
+func init() {
+	applet.Register("exit", Main)
+}
+
+// Main запускает утилиту exit
+func Main() {
+	var help, version bool
+
+	opts := getopt.New("exit")
+	opts.Bool(&help, 'h', "help")
+	opts.Bool(&version, 'v', "version")
+	opts.Parse(os.Args[1:])
 
-	if *help || *helpLong {
+	if help {
 		printHelp()
 		os.Exit(0)
 	}
 	
-	if *version || *versionLong {
+	if version {
 		printVersion()
 		os.Exit(0)
 	}
@@ -1,9 +1,12 @@
-package main
+package file
 
 import (
 	"fmt"
 	"os"
 	"path/filepath"
+
+	"github.com/mir-yks/LinuxCommandAnalog/internal/applet"
+	"github.com/mir-yks/LinuxCommandAnalog/internal/getopt"
 )
 
 type Config struct {
@@ -14,7 +17,12 @@ type Config struct {
 
 const ver = "1.0.0"
 
-func main() {
+func init() {
+	applet.Register("file", Main)
+}
+
+// Main запускает утилиту file
+func Main() {
 	defer func() {
 		if r := recover(); r != nil {
 			fmt.Fprintf(os.Stderr, "Ошибка: %v\n", r)
@@ -34,47 +42,24 @@ func main() {
 		return
 	}
 
-	if len(config.Filenames) == 0 {
-		fmt.Fprintln(os.Stderr, "file: пропущен операнд, задающий файл")
-		fmt.Fprintln(os.Stderr, "По команде «file -h» можно получить дополнительную информацию.")
-		os.Exit(1)
-	}
-
 	executeFile(config)
 }
 
-// parseArgs разбирает аргументы командной строки вручную
+// parseArgs разбирает аргументы командной строки
 func parseArgs() *Config {
 	config := &Config{}
-	filenames := []string{}
-
-	for i := 1; i < len(os.Args); i++ {
-		arg := os.Args[i]
-
-		switch arg {
-		case "-h":
-			config.Help = true
-		case "-v", "--version":
-			config.Version = true
-		default:
-			if len(arg) > 1 && arg[0] == '-' {
-				for _, ch := range arg[1:] {
-					switch ch {
-					case 'h':
-						config.Help = true
-					case 'v':
-						config.Version = true
-					default:
-						panic(fmt.Sprintf("file: неверный ключ — '%s'", arg))
-					}
-				}
-			} else {
-				filenames = append(filenames, arg)
-			}
-		}
-	}
 
-	config.Filenames = filenames
+	opts := getopt.New("file")
+	opts.Bool(&config.Help, 'h', "help")
+	opts.Bool(&config.Version, 'v', "version")
+	config.Filenames = opts.Parse(os.Args[1:])
+
+	if config.Help || config.Version {
+		return config
+	}
+	if len(config.Filenames) == 0 {
+		opts.Failf("пропущен операнд, задающий файл")
+	}
 	return config
 }
 
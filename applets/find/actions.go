@@ -0,0 +1,423 @@
+package find
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"os/exec"
+	"os/user"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/mir-yks/LinuxCommandAnalog/internal/fsmode"
+	"github.com/mir-yks/LinuxCommandAnalog/internal/input"
+)
+
+// argMax - предел суммарной длины путей в одном запуске "-exec ... {} +";
+// заметно меньше ARG_MAX, чтобы хватило места окружению
+const argMax = 128 * 1024
+
+// printAction: -print - вывести путь и перевод строки
+func printAction() node {
+	return testNode{"-print", func(f *file) bool {
+		out.WriteString(f.path)
+		out.WriteByte('\n')
+		return true
+	}}
+}
+
+func printPrimary(p *parser, name string) (node, error) {
+	p.hasAction = true
+	return printAction(), nil
+}
+
+// print0Action: -print0 - путь, завершенный нулевым байтом, для xargs -0
+func print0Action(p *parser, name string) (node, error) {
+	p.hasAction = true
+	return testNode{name, func(f *file) bool {
+		out.WriteString(f.path)
+		out.WriteByte(0)
+		return true
+	}}, nil
+}
+
+// deleteAction: -delete - удалить файл или пустую директорию.
+// Включает -depth, чтобы содержимое директории удалялось раньше нее.
+func deleteAction(p *parser, name string) (node, error) {
+	p.hasAction = true
+	p.config.Depth = true
+	config := p.config
+	return testNode{name, func(f *file) bool {
+		// Начальную точку "." удалить нельзя, GNU find ее пропускает
+		if f.path == "." {
+			return true
+		}
+		if err := os.Remove(f.path); err != nil {
+			out.Flush()
+			fmt.Fprintf(os.Stderr, "find: не удалось удалить '%s': %v\n", f.path, input.Describe(err))
+			config.Failed = true
+			return false
+		}
+		return true
+	}}, nil
+}
+
+// execAction: -exec КОМАНДА ; и -ok КОМАНДА ; запускают команду для
+// каждого файла, заменяя {} путем, и истинны, если команда завершилась
+// успешно. -exec КОМАНДА {} + собирает пути и запускает команду пачками.
+func execAction(p *parser, name string) (node, error) {
+	p.hasAction = true
+
+	var command []string
+	batch := false
+	for {
+		if p.pos >= len(p.args) {
+			return nil, fmt.Errorf("отсутствует аргумент для '%s'", name)
+		}
+		arg := p.args[p.pos]
+		p.pos++
+		if arg == ";" {
+			break
+		}
+		if arg == "+" && len(command) > 0 && command[len(command)-1] == "{}" && name == "-exec" {
+			command = command[:len(command)-1]
+			batch = true
+			break
+		}
+		command = append(command, arg)
+	}
+	if len(command) == 0 {
+		return nil, fmt.Errorf("отсутствует команда для '%s'", name)
+	}
+
+	if batch {
+		b := &execBatch{command: command, config: p.config}
+		p.config.batches = append(p.config.batches, b)
+		return testNode{name, func(f *file) bool {
+			b.add(f.path)
+			return true
+		}}, nil
+	}
+
+	return testNode{name, func(f *file) bool {
+		args := make([]string, len(command))
+		for i, arg := range command {
+			args[i] = strings.ReplaceAll(arg, "{}", f.path)
+		}
+		if name == "-ok" && !confirm(args) {
+			return false
+		}
+		return run(args)
+	}}, nil
+}
+
+// execBatch копит пути для "-exec КОМАНДА {} +"
+type execBatch struct {
+	command []string
+	paths   []string
+	size    int
+	config  *Config
+}
+
+// add добавляет путь, запуская команду, если пачка стала слишком длинной
+func (b *execBatch) add(path string) {
+	if len(b.paths) > 0 && b.size+len(path)+1 > argMax {
+		b.flush()
+	}
+	b.paths = append(b.paths, path)
+	b.size += len(path) + 1
+}
+
+// flush запускает команду для накопленных путей
+func (b *execBatch) flush() {
+	if len(b.paths) == 0 {
+		return
+	}
+	args := append(append([]string{}, b.command...), b.paths...)
+	if !run(args) {
+		b.config.Failed = true
+	}
+	b.paths = b.paths[:0]
+	b.size = 0
+}
+
+// run запускает команду с унаследованными стандартными потоками
+func run(args []string) bool {
+	// Все, что find вывел раньше, должно оказаться перед выводом команды
+	out.Flush()
+
+	cmd := exec.Command(args[0], args[1:]...)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	err := cmd.Run()
+	if err != nil {
+		if _, exited := err.(*exec.ExitError); !exited {
+			fmt.Fprintf(os.Stderr, "find: '%s': %v\n", args[0], err)
+		}
+		return false
+	}
+	return true
+}
+
+// stdin читает ответы на вопросы -ok
+var stdin *bufio.Reader
+
+// confirm спрашивает у пользователя, запускать ли команду
+func confirm(args []string) bool {
+	out.Flush()
+	fmt.Fprintf(os.Stderr, "< %s > ? ", strings.Join(args, " "))
+	if stdin == nil {
+		stdin = bufio.NewReader(os.Stdin)
+	}
+	answer, _ := stdin.ReadString('\n')
+	answer = strings.TrimSpace(answer)
+	return strings.HasPrefix(answer, "y") || strings.HasPrefix(answer, "Y") ||
+		strings.HasPrefix(answer, "д") || strings.HasPrefix(answer, "Д")
+}
+
+// printfAction: -printf ФОРМАТ - вывод по формату с директивами %
+func printfAction(p *parser, name string) (node, error) {
+	format, err := p.arg(name)
+	if err != nil {
+		return nil, err
+	}
+	pieces, err := compileFormat(format)
+	if err != nil {
+		return nil, err
+	}
+	p.hasAction = true
+	return testNode{name, func(f *file) bool {
+		for _, piece := range pieces {
+			out.WriteString(piece(f))
+		}
+		return true
+	}}, nil
+}
+
+// compileFormat разбирает формат -printf один раз при разборе выражения,
+// превращая его в список функций, каждая из которых выводит свой кусок
+func compileFormat(format string) ([]func(*file) string, error) {
+	var pieces []func(*file) string
+	var text strings.Builder
+
+	literal := func() {
+		if text.Len() > 0 {
+			s := text.String()
+			pieces = append(pieces, func(*file) string { return s })
+			text.Reset()
+		}
+	}
+
+	for i := 0; i < len(format); i++ {
+		c := format[i]
+		switch {
+		case c == '\\' && i+1 < len(format):
+			i++
+			if escaped, ok := escapes[format[i]]; ok {
+				text.WriteByte(escaped)
+			} else {
+				text.WriteByte('\\')
+				text.WriteByte(format[i])
+			}
+		case c == '%' && i+1 < len(format) && format[i+1] == '%':
+			i++
+			text.WriteByte('%')
+		case c == '%':
+			// Флаги и ширина поля: %-10p, %5s
+			j := i + 1
+			for j < len(format) && strings.IndexByte("-+ #0123456789.", format[j]) >= 0 {
+				j++
+			}
+			if j == len(format) {
+				return nil, fmt.Errorf("незавершенная директива в формате -printf: '%s'", format[i:])
+			}
+			width := format[i+1 : j]
+
+			directive := format[j]
+			var timeSpec byte
+			if directive == 'T' && j+1 < len(format) {
+				j++
+				timeSpec = format[j]
+			}
+			// Неизвестная директива, как в GNU find, - не ошибка: после
+			// предупреждения она выводится как есть
+			fn, err := formatDirective(directive, timeSpec)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "find: предупреждение: %v\n", err)
+				text.WriteString(format[i : j+1])
+				i = j
+				continue
+			}
+
+			literal()
+			if width != "" {
+				spec := "%" + width + "s"
+				inner := fn
+				fn = func(f *file) string { return fmt.Sprintf(spec, inner(f)) }
+			}
+			pieces = append(pieces, fn)
+			i = j
+		default:
+			text.WriteByte(c)
+		}
+	}
+	literal()
+	return pieces, nil
+}
+
+// escapes - управляющие последовательности формата -printf
+var escapes = map[byte]byte{
+	'n':  '\n',
+	't':  '\t',
+	'r':  '\r',
+	'a':  '\a',
+	'b':  '\b',
+	'f':  '\f',
+	'v':  '\v',
+	'0':  0,
+	'\\': '\\',
+}
+
+// stat возвращает syscall.Stat_t файла или пустую структуру
+func stat(f *file) *syscall.Stat_t {
+	if st, ok := f.info.Sys().(*syscall.Stat_t); ok {
+		return st
+	}
+	return &syscall.Stat_t{}
+}
+
+// formatDirective возвращает функцию для директивы -printf
+func formatDirective(directive, timeSpec byte) (func(*file) string, error) {
+	switch directive {
+	case 'p':
+		return func(f *file) string { return f.path }, nil
+	case 'P':
+		// Путь относительно начальной точки
+		return func(f *file) string {
+			if f.depth == 0 {
+				return ""
+			}
+			return f.path[len(strings.TrimSuffix(f.root, "/"))+1:]
+		}, nil
+	case 'f':
+		return func(f *file) string {
+			_, name := splitPath(f.path)
+			return name
+		}, nil
+	case 'h':
+		return func(f *file) string {
+			dir, _ := splitPath(f.path)
+			return dir
+		}, nil
+	case 'H':
+		return func(f *file) string { return f.root }, nil
+	case 'd':
+		return func(f *file) string { return strconv.Itoa(f.depth) }, nil
+	case 's':
+		return func(f *file) string { return strconv.FormatInt(f.info.Size(), 10) }, nil
+	case 'k':
+		return func(f *file) string { return strconv.FormatInt((int64(stat(f).Blocks)+1)/2, 10) }, nil
+	case 'm':
+		return func(f *file) string { return strconv.FormatUint(uint64(fsmode.Perm(f.info.Mode())), 8) }, nil
+	case 'M':
+		return func(f *file) string { return fsmode.String(f.info.Mode()) }, nil
+	case 'y':
+		return func(f *file) string { return string(fsmode.TypeLetter(f.info.Mode())) }, nil
+	case 'i':
+		return func(f *file) string { return strconv.FormatUint(uint64(stat(f).Ino), 10) }, nil
+	case 'n':
+		return func(f *file) string { return strconv.FormatUint(uint64(stat(f).Nlink), 10) }, nil
+	case 'U':
+		return func(f *file) string { return strconv.FormatUint(uint64(stat(f).Uid), 10) }, nil
+	case 'G':
+		return func(f *file) string { return strconv.FormatUint(uint64(stat(f).Gid), 10) }, nil
+	case 'u':
+		return func(f *file) string {
+			uid := strconv.FormatUint(uint64(stat(f).Uid), 10)
+			if u, err := user.LookupId(uid); err == nil {
+				return u.Username
+			}
+			return uid
+		}, nil
+	case 'g':
+		return func(f *file) string {
+			gid := strconv.FormatUint(uint64(stat(f).Gid), 10)
+			if g, err := user.LookupGroupId(gid); err == nil {
+				return g.Name
+			}
+			return gid
+		}, nil
+	case 'l':
+		return func(f *file) string {
+			target, _ := os.Readlink(f.path)
+			return target
+		}, nil
+	case 't':
+		return func(f *file) string { return ctime(f.info.ModTime()) }, nil
+	case 'T':
+		layout, ok := timeFields[timeSpec]
+		if timeSpec == '@' {
+			return func(f *file) string {
+				t := f.info.ModTime()
+				return fmt.Sprintf("%d.%09d0", t.Unix(), t.Nanosecond())
+			}, nil
+		}
+		if timeSpec == 0 {
+			return nil, fmt.Errorf("после %%T нет поля времени")
+		}
+		if !ok {
+			return nil, fmt.Errorf("неизвестное поле времени %%T%c", timeSpec)
+		}
+		if strings.IndexByte("ST+", timeSpec) >= 0 {
+			// У секунд GNU find выводит дробную часть
+			return func(f *file) string {
+				t := f.info.ModTime()
+				return fmt.Sprintf("%s.%09d0", t.Format(layout), t.Nanosecond())
+			}, nil
+		}
+		return func(f *file) string { return f.info.ModTime().Format(layout) }, nil
+	}
+	return nil, fmt.Errorf("неизвестная директива %%%c в формате -printf", directive)
+}
+
+// splitPath делит путь на директорию и имя так же, как %h и %f в GNU find:
+// завершающие '/' остаются в имени, у "/bin" директория пустая
+func splitPath(path string) (dir, name string) {
+	trimmed := strings.TrimRight(path, "/")
+	if trimmed == "" {
+		return path[:len(path)-1], "/"
+	}
+	slash := strings.LastIndexByte(trimmed, '/')
+	if slash < 0 {
+		return ".", path
+	}
+	return path[:slash], path[slash+1:]
+}
+
+// ctime форматирует время как %t в GNU find: Sat Oct 17 03:04:48.1993954570 2026
+func ctime(t time.Time) string {
+	return fmt.Sprintf("%s.%09d0 %s", t.Format("Mon Jan _2 15:04:05"), t.Nanosecond(), t.Format("2006"))
+}
+
+// timeFields - поля %Tk в виде раскладок time.Format
+var timeFields = map[byte]string{
+	'Y': "2006",
+	'y': "06",
+	'm': "01",
+	'd': "02",
+	'H': "15",
+	'M': "04",
+	'S': "05",
+	'b': "Jan",
+	'B': "January",
+	'a': "Mon",
+	'A': "Monday",
+	'Z': "MST",
+	'F': "2006-01-02",
+	'T': "15:04:05",
+	'D': "01/02/06",
+	'+': "2006-01-02+15:04:05",
+}
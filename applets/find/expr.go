@@ -0,0 +1,191 @@
+package find
+
+import (
+	"fmt"
+	"io/fs"
+	"strings"
+
+	"github.com/mir-yks/LinuxCommandAnalog/internal/walk"
+)
+
+// file - файл, для которого вычисляется выражение
+type file struct {
+	path  string
+	root  string      // начальная точка, из которой найден файл
+	info  fs.FileInfo // сведения lstat: ссылки не раскрываются
+	depth int         // 0 для начальной точки поиска
+	prune bool        // -prune запретил спуск в директорию
+}
+
+func newFile(e *walk.Entry) *file {
+	return &file{path: e.Path, root: e.Root, info: e.Info, depth: e.Depth}
+}
+
+// node - узел дерева выражения. Операторы вычисляются лениво, как в find:
+// в "A -o B" правая часть не вычисляется, если левая истинна.
+type node interface {
+	eval(f *file) bool
+}
+
+type andNode struct{ left, right node }
+type orNode struct{ left, right node }
+type notNode struct{ operand node }
+
+// testNode - проверка или действие: -name, -type, -print и т.д.
+type testNode struct {
+	name string
+	fn   func(f *file) bool
+}
+
+func (n andNode) eval(f *file) bool  { return n.left.eval(f) && n.right.eval(f) }
+func (n orNode) eval(f *file) bool   { return n.left.eval(f) || n.right.eval(f) }
+func (n notNode) eval(f *file) bool  { return !n.operand.eval(f) }
+func (n testNode) eval(f *file) bool { return n.fn(f) }
+
+// alwaysTrue - узел для глобальных опций вроде -maxdepth, которые ничего не проверяют
+var alwaysTrue = testNode{name: "-true", fn: func(*file) bool { return true }}
+
+// parser разбирает выражение find методом рекурсивного спуска.
+// Приоритет операторов по убыванию: ( ), !, -a (в том числе неявный), -o.
+type parser struct {
+	args      []string
+	pos       int
+	config    *Config
+	hasAction bool // в выражении есть действие, и -print по умолчанию не нужен
+}
+
+// parseExpression строит дерево выражения. Если в нем нет ни одного действия,
+// выражение оборачивается в "( выражение ) -print", как в POSIX find.
+func parseExpression(args []string, config *Config) (node, error) {
+	p := &parser{args: args, config: config}
+
+	var root node = alwaysTrue
+	if len(args) > 0 {
+		var err error
+		if root, err = p.parseOr(); err != nil {
+			return nil, err
+		}
+		if p.pos < len(p.args) {
+			if p.args[p.pos] == ")" {
+				return nil, fmt.Errorf("лишняя закрывающая скобка ')'")
+			}
+			return nil, fmt.Errorf("неожиданный аргумент '%s'", p.args[p.pos])
+		}
+	}
+
+	if !p.hasAction {
+		root = andNode{root, printAction()}
+	}
+	return root, nil
+}
+
+func (p *parser) peek() string {
+	if p.pos < len(p.args) {
+		return p.args[p.pos]
+	}
+	return ""
+}
+
+func (p *parser) parseOr() (node, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek() == "-o" || p.peek() == "-or" {
+		op := p.args[p.pos]
+		p.pos++
+		if p.pos == len(p.args) {
+			return nil, fmt.Errorf("после оператора '%s' нет выражения", op)
+		}
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = orNode{left, right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseAnd() (node, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for p.pos < len(p.args) {
+		switch p.peek() {
+		case "-o", "-or", ")":
+			return left, nil
+		case "-a", "-and":
+			p.pos++
+			if p.pos == len(p.args) {
+				return nil, fmt.Errorf("после оператора '%s' нет выражения", p.args[p.pos-1])
+			}
+		}
+		// Два выражения подряд соединяются неявным -a
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = andNode{left, right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseUnary() (node, error) {
+	switch tok := p.peek(); tok {
+	case "!", "-not":
+		p.pos++
+		if p.pos == len(p.args) {
+			return nil, fmt.Errorf("после оператора '%s' нет выражения", tok)
+		}
+		operand, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return notNode{operand}, nil
+	case "-a", "-and", "-o", "-or":
+		return nil, fmt.Errorf("перед бинарным оператором '%s' нет выражения", tok)
+	}
+	return p.parsePrimary()
+}
+
+func (p *parser) parsePrimary() (node, error) {
+	tok := p.args[p.pos]
+	p.pos++
+
+	switch tok {
+	case "(":
+		if p.peek() == ")" {
+			return nil, fmt.Errorf("пустые скобки '( )' недопустимы")
+		}
+		inner, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if p.peek() != ")" {
+			return nil, fmt.Errorf("нет закрывающей скобки ')'")
+		}
+		p.pos++
+		return inner, nil
+	case ")":
+		return nil, fmt.Errorf("неожиданная закрывающая скобка ')'")
+	}
+
+	build, ok := primaries[tok]
+	if !ok && !strings.HasPrefix(tok, "-") {
+		return nil, fmt.Errorf("пути должны предшествовать выражению: '%s' (шаблон не взят в кавычки?)", tok)
+	}
+	if !ok {
+		return nil, fmt.Errorf("неизвестный предикат '%s'", tok)
+	}
+	return build(p, tok)
+}
+
+// arg возвращает аргумент предиката
+func (p *parser) arg(name string) (string, error) {
+	if p.pos >= len(p.args) {
+		return "", fmt.Errorf("отсутствует аргумент для '%s'", name)
+	}
+	p.pos++
+	return p.args[p.pos-1], nil
+}
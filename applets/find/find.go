@@ -0,0 +1,214 @@
+package find
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/mir-yks/LinuxCommandAnalog/internal/applet"
+	"github.com/mir-yks/LinuxCommandAnalog/internal/walk"
+)
+
+type Config struct {
+	Help      bool
+	Version   bool
+	Paths     []string  // начальные точки поиска
+	Expr      node      // дерево выражения
+	MaxDepth  int       // -maxdepth; -1 - без ограничения
+	MinDepth  int       // -mindepth
+	Depth     bool      // -depth: директория обрабатывается после своего содержимого
+	XDev      bool      // -xdev: не спускаться в другие файловые системы
+	Unordered bool      // -unordered: выводить в порядке готовности, а не по имени
+	Now       time.Time // момент запуска, от которого считают -mtime и -mmin
+	Failed    bool      // действие завершилось ошибкой; find вернет код 1
+
+	batches []*execBatch // команды "-exec ... {} +", запускаемые пачками
+}
+
+const version = "1.0.0"
+
+// out буферизует вывод путей; сбрасывается перед завершением
+var out = bufio.NewWriter(os.Stdout)
+
+func init() {
+	applet.Register("find", Main)
+}
+
+// Main запускает утилиту find
+func Main() {
+	defer func() {
+		if r := recover(); r != nil {
+			out.Flush()
+			fmt.Fprintf(os.Stderr, "find: критическая ошибка: %v\n", r)
+			os.Exit(1)
+		}
+	}()
+
+	config, err := parseArgs(os.Args[1:])
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "find: %v\n", err)
+		os.Exit(1)
+	}
+
+	if config.Help {
+		printHelp()
+		return
+	}
+
+	if config.Version {
+		printVersion()
+		return
+	}
+
+	ok := findFiles(config)
+	out.Flush()
+	if !ok {
+		os.Exit(1)
+	}
+}
+
+// parseArgs разбирает командную строку find: сначала начальные точки,
+// затем выражение. Выражение начинается с первого аргумента, похожего на
+// предикат или оператор: "-...", "!" или "(".
+func parseArgs(args []string) (*Config, error) {
+	config := &Config{MaxDepth: -1, Now: time.Now()}
+
+	if len(args) > 0 {
+		switch args[0] {
+		case "-h", "-help", "--help":
+			config.Help = true
+			return config, nil
+		case "-version", "--version":
+			config.Version = true
+			return config, nil
+		}
+	}
+
+	i := 0
+	for i < len(args) && !isExpressionStart(args[i]) {
+		i++
+	}
+	config.Paths = args[:i]
+	if len(config.Paths) == 0 {
+		config.Paths = []string{"."}
+	}
+
+	expr, err := parseExpression(args[i:], config)
+	if err != nil {
+		return nil, err
+	}
+	config.Expr = expr
+	return config, nil
+}
+
+// isExpressionStart сообщает, что аргумент начинает выражение, а не путь
+func isExpressionStart(arg string) bool {
+	return (strings.HasPrefix(arg, "-") && arg != "-") || arg == "!" || arg == "(" || arg == ")"
+}
+
+func printHelp() {
+	fmt.Println("find - ищет файлы в дереве директорий по выражению")
+	fmt.Println()
+	fmt.Println("Использование: find [ПУТЬ]... [ВЫРАЖЕНИЕ]")
+	fmt.Println()
+	fmt.Println("По умолчанию ПУТЬ - текущая директория, а ВЫРАЖЕНИЕ - -print.")
+	fmt.Println()
+	fmt.Println("Операторы (по убыванию приоритета):")
+	fmt.Println("  ( ВЫР )          группировка (скобки экранируются в оболочке: \\( \\))")
+	fmt.Println("  ! ВЫР, -not ВЫР  отрицание")
+	fmt.Println("  ВЫР1 -a ВЫР2     и (оператор можно опустить)")
+	fmt.Println("  ВЫР1 -o ВЫР2     или")
+	fmt.Println()
+	fmt.Println("Проверки (N: +N - больше N, -N - меньше N, N - ровно N):")
+	fmt.Println("  -name ШАБЛОН     имя файла по шаблону оболочки (*, ?, [a-z])")
+	fmt.Println("  -iname ШАБЛОН    то же без учета регистра")
+	fmt.Println("  -path ШАБЛОН     весь путь по шаблону; * совпадает и с /")
+	fmt.Println("  -ipath ШАБЛОН    то же без учета регистра")
+	fmt.Println("  -regex RE        весь путь по регулярному выражению (-iregex без учета регистра)")
+	fmt.Println("  -type ТИП        f - файл, d - директория, l - ссылка, p, s, b, c; можно f,l")
+	fmt.Println("  -size N[cwbkMG]  размер в единицах (по умолчанию 512-байтные блоки)")
+	fmt.Println("  -mtime N         изменен N суток назад")
+	fmt.Println("  -mmin N          изменен N минут назад")
+	fmt.Println("  -newer ФАЙЛ      изменен позже ФАЙЛА")
+	fmt.Println("  -user ИМЯ        принадлежит пользователю (имя или UID)")
+	fmt.Println("  -perm РЕЖИМ      права ровно РЕЖИМ; -РЕЖИМ - все биты, /РЕЖИМ - любой бит")
+	fmt.Println("  -empty           пустой файл или директория")
+	fmt.Println("  -true, -false    всегда истинно или ложно")
+	fmt.Println()
+	fmt.Println("Действия:")
+	fmt.Println("  -print           вывести путь (по умолчанию)")
+	fmt.Println("  -print0          вывести путь и нулевой байт (для xargs -0)")
+	fmt.Printf("  -printf ФОРМАТ   вывести по формату: %%p путь, %%f имя, %%h директория, %%s размер,\n")
+	fmt.Printf("                   %%t время изменения, %%TY..%%TS его поля, %%m права (восьмерично),\n")
+	fmt.Printf("                   %%M права (rwx), %%u владелец, %%g группа, %%y тип, %%d глубина;\n")
+	fmt.Println("                   \\n, \\t, \\0 - перевод строки, табуляция, нулевой байт")
+	fmt.Println("  -delete          удалить файл или пустую директорию (включает -depth)")
+	fmt.Println("  -exec КОМ ;      выполнить КОМанду, заменяя {} путем; истинно при успехе")
+	fmt.Println("  -exec КОМ {} +   выполнить КОМанду один раз для многих путей")
+	fmt.Println("  -ok КОМ ;        как -exec, но сначала спросить подтверждение")
+	fmt.Println("  -prune           не спускаться в директорию")
+	fmt.Println()
+	fmt.Println("Опции:")
+	fmt.Println("  -depth           обрабатывать содержимое директории раньше нее")
+	fmt.Println("  -maxdepth N      спускаться не глубже N уровней")
+	fmt.Println("  -mindepth N      не проверять файлы ближе N уровней")
+	fmt.Println("  -xdev, -mount    не спускаться в директории других файловых систем")
+	fmt.Println("  -unordered       выводить файлы по мере нахождения, а не по порядку имен;")
+	fmt.Println("                   быстрее на больших деревьях и сетевых дисках")
+	fmt.Println()
+	fmt.Println("  -h, --help       показать справку")
+	fmt.Println("  --version        показать информацию о версии")
+	fmt.Println()
+	fmt.Println("Примеры:")
+	fmt.Println("  find . -name '*.go'                          # все .go файлы")
+	fmt.Println("  find /tmp -type f -size +1M -mtime +7        # большие старые файлы")
+	fmt.Println("  find . -name .git -prune -o -type f -print   # без содержимого .git")
+	fmt.Println("  find . \\( -name '*.o' -o -name '*.a' \\) -user root")
+	fmt.Println("  find . -name '*.tmp' -delete                 # удалить временные файлы")
+	fmt.Println("  find . -name '*.go' -exec grep -l TODO {} +  # без xargs, пробелы не мешают")
+	fmt.Printf("  find . -type f -printf '%%s\\t%%p\\n'           # размер и путь\n")
+}
+
+func printVersion() {
+	fmt.Println("find версия", version)
+	fmt.Println("Разработано в рамках учебного проекта")
+	fmt.Println("Язык программирования: Golang")
+}
+
+// findFiles обходит все начальные точки; возвращает false, если были ошибки
+// доступа или действия. Выражение для директории вычисляется до ее
+// содержимого, а при -depth - после, и тогда -prune не действует.
+func findFiles(config *Config) bool {
+	ok := true
+
+	walk.Walk(config.Paths, walk.Options{
+		Sorted:   !config.Unordered,
+		SameFS:   config.XDev,
+		MaxDepth: config.MaxDepth,
+		Enter: func(e *walk.Entry) bool {
+			if config.Depth || e.Depth < config.MinDepth {
+				return true
+			}
+			f := newFile(e)
+			config.Expr.eval(f)
+			return !f.prune
+		},
+		Leave: func(e *walk.Entry) {
+			if config.Depth && e.Depth >= config.MinDepth {
+				config.Expr.eval(newFile(e))
+			}
+		},
+		Error: func(path string, err error) {
+			out.Flush()
+			fmt.Fprintf(os.Stderr, "find: '%s': %v\n", path, err)
+			ok = false
+		},
+	})
+
+	for _, batch := range config.batches {
+		batch.flush()
+	}
+	return ok && !config.Failed
+}
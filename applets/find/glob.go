@@ -0,0 +1,107 @@
+package find
+
+import (
+	"unicode/utf8"
+)
+
+// globMatch сопоставляет имя с шаблоном оболочки, как fnmatch без флагов:
+// * - любая последовательность символов (в том числе '/', что нужно для -path),
+// ? - один символ, [abc], [a-z], [!a-z] или [^a-z] - класс символов,
+// \ экранирует следующий символ
+func globMatch(pattern, name string) bool {
+	// Позиции для возврата к последней звездочке: при несовпадении она
+	// поглощает еще один символ имени
+	starPattern, starName := -1, -1
+	p, n := 0, 0
+
+	for n < len(name) {
+		if p < len(pattern) {
+			switch pattern[p] {
+			case '*':
+				starPattern, starName = p, n
+				p++
+				continue
+			case '?':
+				_, size := utf8.DecodeRuneInString(name[n:])
+				p++
+				n += size
+				continue
+			case '[':
+				r, size := utf8.DecodeRuneInString(name[n:])
+				if matched, end, ok := matchClass(pattern[p:], r); ok {
+					if matched {
+						p += end
+						n += size
+						continue
+					}
+				} else if name[n] == '[' {
+					// Незакрытая скобка сравнивается как обычный символ
+					p++
+					n++
+					continue
+				}
+			default:
+				pc, psize := literal(pattern[p:])
+				r, size := utf8.DecodeRuneInString(name[n:])
+				if pc == r {
+					p += psize
+					n += size
+					continue
+				}
+			}
+		}
+
+		if starPattern < 0 {
+			return false
+		}
+		_, size := utf8.DecodeRuneInString(name[starName:])
+		starName += size
+		p, n = starPattern+1, starName
+	}
+
+	for p < len(pattern) && pattern[p] == '*' {
+		p++
+	}
+	return p == len(pattern)
+}
+
+// literal возвращает очередной символ шаблона с учетом экранирования
+func literal(pattern string) (rune, int) {
+	if pattern[0] == '\\' && len(pattern) > 1 {
+		r, size := utf8.DecodeRuneInString(pattern[1:])
+		return r, size + 1
+	}
+	return utf8.DecodeRuneInString(pattern)
+}
+
+// matchClass проверяет символ по классу [...] в начале шаблона и возвращает
+// длину класса; ok=false, если закрывающей скобки нет
+func matchClass(pattern string, r rune) (matched bool, end int, ok bool) {
+	i := 1
+	negate := false
+	if i < len(pattern) && (pattern[i] == '!' || pattern[i] == '^') {
+		negate = true
+		i++
+	}
+
+	first := true
+	for i < len(pattern) {
+		// ']' сразу после открывающей скобки - обычный символ класса
+		if pattern[i] == ']' && !first {
+			return matched != negate, i + 1, true
+		}
+		first = false
+
+		lo, size := literal(pattern[i:])
+		i += size
+		hi := lo
+		if i+1 < len(pattern) && pattern[i] == '-' && pattern[i+1] != ']' {
+			hi, size = literal(pattern[i+1:])
+			i += 1 + size
+		}
+		if lo <= r && r <= hi {
+			matched = true
+		}
+	}
+	return false, 0, false
+}
@@ -0,0 +1,466 @@
+package find
+
+import (
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"os/user"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/mir-yks/LinuxCommandAnalog/internal/fsmode"
+	"github.com/mir-yks/LinuxCommandAnalog/internal/input"
+)
+
+// primaries - предикаты выражения: имя -> функция, которая читает аргументы
+// предиката из parser и возвращает узел дерева
+var primaries map[string]func(p *parser, name string) (node, error)
+
+func init() {
+	primaries = map[string]func(p *parser, name string) (node, error){
+		"-name":       nameTest,
+		"-iname":      nameTest,
+		"-path":       pathTest,
+		"-ipath":      pathTest,
+		"-wholename":  pathTest,
+		"-iwholename": pathTest,
+		"-regex":      regexTest,
+		"-iregex":     regexTest,
+		"-type":       typeTest,
+		"-size":       sizeTest,
+		"-mtime":      timeTest,
+		"-mmin":       timeTest,
+		"-newer":      newerTest,
+		"-user":       userTest,
+		"-perm":       permTest,
+		"-empty":      emptyTest,
+		"-prune":      pruneTest,
+		"-maxdepth":   depthOption,
+		"-mindepth":   depthOption,
+		"-true":       constTest,
+		"-false":      constTest,
+		"-depth":      depthFirstOption,
+		"-xdev":       xdevOption,
+		"-mount":      xdevOption,
+		"-unordered":  unorderedOption,
+		"-print":      printPrimary,
+		"-print0":     print0Action,
+		"-printf":     printfAction,
+		"-delete":     deleteAction,
+		"-exec":       execAction,
+		"-ok":         execAction,
+	}
+}
+
+// baseName возвращает имя файла без директорий; у "dir/" это "dir"
+func baseName(path string) string {
+	if trimmed := strings.TrimRight(path, "/"); trimmed != "" {
+		return filepath.Base(trimmed)
+	}
+	return "/"
+}
+
+// nameTest: -name ШАБЛОН, -iname ШАБЛОН - имя файла без директорий
+func nameTest(p *parser, name string) (node, error) {
+	pattern, err := p.arg(name)
+	if err != nil {
+		return nil, err
+	}
+	if name == "-iname" {
+		pattern = strings.ToLower(pattern)
+		return testNode{name, func(f *file) bool {
+			return globMatch(pattern, strings.ToLower(baseName(f.path)))
+		}}, nil
+	}
+	return testNode{name, func(f *file) bool {
+		return globMatch(pattern, baseName(f.path))
+	}}, nil
+}
+
+// pathTest: -path ШАБЛОН, -ipath ШАБЛОН - весь путь; '*' совпадает и с '/'
+func pathTest(p *parser, name string) (node, error) {
+	pattern, err := p.arg(name)
+	if err != nil {
+		return nil, err
+	}
+	if strings.HasPrefix(name, "-i") {
+		pattern = strings.ToLower(pattern)
+		return testNode{name, func(f *file) bool {
+			return globMatch(pattern, strings.ToLower(f.path))
+		}}, nil
+	}
+	return testNode{name, func(f *file) bool {
+		return globMatch(pattern, f.path)
+	}}, nil
+}
+
+// regexTest: -regex RE, -iregex RE - регулярное выражение должно совпасть со всем путем
+func regexTest(p *parser, name string) (node, error) {
+	expr, err := p.arg(name)
+	if err != nil {
+		return nil, err
+	}
+	flags := ""
+	if name == "-iregex" {
+		flags = "(?i)"
+	}
+	re, err := regexp.Compile(flags + "^(?:" + expr + ")$")
+	if err != nil {
+		return nil, fmt.Errorf("неверное регулярное выражение '%s': %v", expr, err)
+	}
+	return testNode{name, func(f *file) bool {
+		return re.MatchString(f.path)
+	}}, nil
+}
+
+// fileTypes - буквы -type и соответствующие им типы файлов
+var fileTypes = map[string]fs.FileMode{
+	"f": 0,
+	"d": fs.ModeDir,
+	"l": fs.ModeSymlink,
+	"p": fs.ModeNamedPipe,
+	"s": fs.ModeSocket,
+	"b": fs.ModeDevice,
+	"c": fs.ModeDevice | fs.ModeCharDevice,
+}
+
+// typeTest: -type f|d|l|p|s|b|c, несколько типов через запятую: -type f,l
+func typeTest(p *parser, name string) (node, error) {
+	value, err := p.arg(name)
+	if err != nil {
+		return nil, err
+	}
+
+	var types []fs.FileMode
+	for _, letter := range strings.Split(value, ",") {
+		t, ok := fileTypes[letter]
+		if !ok {
+			return nil, fmt.Errorf("неизвестный аргумент -type: '%s'", letter)
+		}
+		types = append(types, t)
+	}
+
+	return testNode{name, func(f *file) bool {
+		kind := f.info.Mode().Type()
+		for _, t := range types {
+			if kind == t {
+				return true
+			}
+		}
+		return false
+	}}, nil
+}
+
+// numeric - числовой аргумент вида +N (больше N), -N (меньше N) или N (ровно N)
+type numeric struct {
+	sign byte
+	n    int64
+}
+
+// parseNumeric разбирает числовой аргумент; suffix - допустимые буквы
+// единиц после числа, найденная буква возвращается отдельно
+func parseNumeric(name, value, suffixes string) (numeric, byte, error) {
+	var num numeric
+	digits := value
+	if digits != "" && (digits[0] == '+' || digits[0] == '-') {
+		num.sign = digits[0]
+		digits = digits[1:]
+	}
+
+	var unit byte
+	if digits != "" && strings.IndexByte(suffixes, digits[len(digits)-1]) >= 0 {
+		unit = digits[len(digits)-1]
+		digits = digits[:len(digits)-1]
+	}
+
+	n, err := strconv.ParseInt(digits, 10, 64)
+	if err != nil || n < 0 {
+		return num, 0, fmt.Errorf("неверный аргумент '%s' для '%s'", value, name)
+	}
+	num.n = n
+	return num, unit, nil
+}
+
+func (num numeric) match(value int64) bool {
+	switch num.sign {
+	case '+':
+		return value > num.n
+	case '-':
+		return value < num.n
+	}
+	return value == num.n
+}
+
+// sizeUnits - единицы -size; без буквы размер считается в 512-байтных блоках
+var sizeUnits = map[byte]int64{
+	'c': 1,
+	'w': 2,
+	'b': 512,
+	'k': 1024,
+	'M': 1024 * 1024,
+	'G': 1024 * 1024 * 1024,
+}
+
+// sizeTest: -size [+-]N[cwbkMG]. Размер округляется вверх до целых единиц,
+// поэтому, как и в GNU find, -size -1M находит только пустые файлы.
+func sizeTest(p *parser, name string) (node, error) {
+	value, err := p.arg(name)
+	if err != nil {
+		return nil, err
+	}
+	num, unit, err := parseNumeric(name, value, "cwbkMG")
+	if err != nil {
+		return nil, err
+	}
+	blockSize := sizeUnits['b']
+	if unit != 0 {
+		blockSize = sizeUnits[unit]
+	}
+
+	return testNode{name, func(f *file) bool {
+		size := f.info.Size()
+		return num.match((size + blockSize - 1) / blockSize)
+	}}, nil
+}
+
+// timeTest: -mtime [+-]N в сутках, -mmin [+-]N в минутах с момента изменения.
+// Дробная часть отбрасывается: -mtime 0 - изменен за последние 24 часа.
+func timeTest(p *parser, name string) (node, error) {
+	value, err := p.arg(name)
+	if err != nil {
+		return nil, err
+	}
+	num, _, err := parseNumeric(name, value, "")
+	if err != nil {
+		return nil, err
+	}
+	period := 24 * time.Hour
+	if name == "-mmin" {
+		period = time.Minute
+	}
+
+	now := p.config.Now
+	return testNode{name, func(f *file) bool {
+		return num.match(int64(now.Sub(f.info.ModTime()) / period))
+	}}, nil
+}
+
+// newerTest: -newer ФАЙЛ - изменен позже, чем ФАЙЛ
+func newerTest(p *parser, name string) (node, error) {
+	ref, err := p.arg(name)
+	if err != nil {
+		return nil, err
+	}
+	info, err := os.Stat(ref)
+	if err != nil {
+		return nil, fmt.Errorf("'%s': %v", ref, input.Describe(err))
+	}
+	refTime := info.ModTime()
+	return testNode{name, func(f *file) bool {
+		return f.info.ModTime().After(refTime)
+	}}, nil
+}
+
+// userTest: -user ИМЯ или -user UID
+func userTest(p *parser, name string) (node, error) {
+	value, err := p.arg(name)
+	if err != nil {
+		return nil, err
+	}
+
+	var uid uint64
+	if u, err := user.Lookup(value); err == nil {
+		uid, _ = strconv.ParseUint(u.Uid, 10, 32)
+	} else if uid, err = strconv.ParseUint(value, 10, 32); err != nil {
+		return nil, fmt.Errorf("'%s' - неизвестный пользователь", value)
+	}
+
+	return testNode{name, func(f *file) bool {
+		st, ok := f.info.Sys().(*syscall.Stat_t)
+		return ok && uint64(st.Uid) == uid
+	}}, nil
+}
+
+// permTest: -perm РЕЖИМ (права в точности равны), -perm -РЕЖИМ (установлены
+// все биты), -perm /РЕЖИМ (установлен хотя бы один). РЕЖИМ - восьмеричный
+// (644) или символьный (u+x,g=rw).
+func permTest(p *parser, name string) (node, error) {
+	value, err := p.arg(name)
+	if err != nil {
+		return nil, err
+	}
+
+	kind := byte(0)
+	spec := value
+	if spec != "" && (spec[0] == '-' || spec[0] == '/') {
+		kind = spec[0]
+		spec = spec[1:]
+	}
+	mode, err := parseMode(spec)
+	if err != nil {
+		return nil, fmt.Errorf("неверный режим '%s'", value)
+	}
+
+	return testNode{name, func(f *file) bool {
+		perm := fsmode.Perm(f.info.Mode())
+		switch kind {
+		case '-':
+			return perm&mode == mode
+		case '/':
+			// Без единого бита -perm /000 истинно для любого файла
+			return mode == 0 || perm&mode != 0
+		}
+		return perm == mode
+	}}, nil
+}
+
+// parseMode разбирает восьмеричный или символьный режим. Символьный режим
+// применяется к пустым правам: "u+x,g+w" дает 0120.
+func parseMode(spec string) (uint32, error) {
+	if spec == "" {
+		return 0, fmt.Errorf("пустой режим")
+	}
+	if spec[0] >= '0' && spec[0] <= '7' {
+		mode, err := strconv.ParseUint(spec, 8, 32)
+		if err != nil || mode > 0o7777 {
+			return 0, fmt.Errorf("неверный восьмеричный режим")
+		}
+		return uint32(mode), nil
+	}
+
+	var mode uint32
+	for _, clause := range strings.Split(spec, ",") {
+		i := 0
+		var who uint32
+	who:
+		for ; i < len(clause); i++ {
+			switch clause[i] {
+			case 'u':
+				who |= 0o4700
+			case 'g':
+				who |= 0o2070
+			case 'o':
+				who |= 0o1007
+			case 'a':
+				who |= 0o7777
+			default:
+				break who
+			}
+		}
+		if who == 0 {
+			who = 0o7777
+		}
+		if i == len(clause) {
+			return 0, fmt.Errorf("нет оператора")
+		}
+
+		for i < len(clause) {
+			op := clause[i]
+			if op != '+' && op != '-' && op != '=' {
+				return 0, fmt.Errorf("неверный оператор")
+			}
+			i++
+
+			var bits uint32
+			for ; i < len(clause) && strings.IndexByte("rwxXst", clause[i]) >= 0; i++ {
+				switch clause[i] {
+				case 'r':
+					bits |= 0o444
+				case 'w':
+					bits |= 0o222
+				case 'x', 'X':
+					bits |= 0o111
+				case 's':
+					bits |= 0o6000
+				case 't':
+					bits |= 0o1000
+				}
+			}
+			bits &= who
+
+			switch op {
+			case '+':
+				mode |= bits
+			case '-':
+				mode &^= bits
+			case '=':
+				mode = mode&^who | bits
+			}
+		}
+	}
+	return mode, nil
+}
+
+// emptyTest: -empty - пустой обычный файл или директория без записей
+func emptyTest(p *parser, name string) (node, error) {
+	return testNode{name, func(f *file) bool {
+		switch {
+		case f.info.Mode().IsRegular():
+			return f.info.Size() == 0
+		case f.info.IsDir():
+			dir, err := os.Open(f.path)
+			if err != nil {
+				return false
+			}
+			defer dir.Close()
+			_, err = dir.Readdirnames(1)
+			return err == io.EOF
+		}
+		return false
+	}}, nil
+}
+
+// pruneTest: -prune - не спускаться в директорию; всегда истинно
+func pruneTest(p *parser, name string) (node, error) {
+	return testNode{name, func(f *file) bool {
+		f.prune = true
+		return true
+	}}, nil
+}
+
+// depthOption: -maxdepth N, -mindepth N - глобальные опции обхода
+func depthOption(p *parser, name string) (node, error) {
+	value, err := p.arg(name)
+	if err != nil {
+		return nil, err
+	}
+	depth, err := strconv.Atoi(value)
+	if err != nil || depth < 0 {
+		return nil, fmt.Errorf("неверный аргумент '%s' для '%s'", value, name)
+	}
+	if name == "-maxdepth" {
+		p.config.MaxDepth = depth
+	} else {
+		p.config.MinDepth = depth
+	}
+	return alwaysTrue, nil
+}
+
+// depthFirstOption: -depth - обрабатывать содержимое директории раньше нее самой
+func depthFirstOption(p *parser, name string) (node, error) {
+	p.config.Depth = true
+	return alwaysTrue, nil
+}
+
+// xdevOption: -xdev и -mount - оставаться в файловой системе начальной точки
+func xdevOption(p *parser, name string) (node, error) {
+	p.config.XDev = true
+	return alwaysTrue, nil
+}
+
+// unorderedOption: -unordered - не упорядочивать обход по именам
+func unorderedOption(p *parser, name string) (node, error) {
+	p.config.Unordered = true
+	return alwaysTrue, nil
+}
+
+// constTest: -true и -false
+func constTest(p *parser, name string) (node, error) {
+	result := name == "-true"
+	return testNode{name, func(*file) bool { return result }}, nil
+}
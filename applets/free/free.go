@@ -0,0 +1,343 @@
+package free
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mir-yks/LinuxCommandAnalog/internal/applet"
+	"github.com/mir-yks/LinuxCommandAnalog/internal/getopt"
+)
+
+type Config struct {
+	Help     bool
+	Version  bool
+	Unit     uint64        // единица вывода в байтах
+	UnitName string        // подпись единицы в заголовке: KiB, MiB
+	Human    int           // 1024 для -h, 1000 для -h --si, 0 - числа в Unit
+	SI       bool          // --si: степени 1000 вместо 1024
+	Wide     bool          // -w: buffers и cache отдельными столбцами
+	Total    bool          // -t: строка Total
+	Interval time.Duration // -s: пауза между выводами; 0 - один вывод
+	Count    int           // -c: сколько раз вывести; 0 - без ограничения
+}
+
+const ver = "1.0.0"
+
+const (
+	KB = 1024
+	MB = 1024 * KB
+	GB = 1024 * MB
+	TB = 1024 * GB
+)
+
+func init() {
+	applet.Register("free", Main)
+}
+
+// Main запускает утилиту free
+func Main() {
+	defer func() {
+		if r := recover(); r != nil {
+			fmt.Fprintf(os.Stderr, "free: %v\n", r)
+			os.Exit(1)
+		}
+	}()
+
+	config := parseArgs()
+
+	if config.Help {
+		printHelp()
+		return
+	}
+
+	if config.Version {
+		printVersion()
+		return
+	}
+
+	if err := executeFree(config); err != nil {
+		fmt.Fprintf(os.Stderr, "free: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// parseArgs разбирает аргументы командной строки
+func parseArgs() *Config {
+	config := &Config{Unit: KB, UnitName: "KiB"}
+
+	// unit задает единицу вывода; --kilo, --mega и т.д. считают по степеням 1000
+	unit := func(size uint64, name string) func(string) error {
+		return func(string) error {
+			config.Unit, config.UnitName, config.Human = size, name, 0
+			return nil
+		}
+	}
+
+	opts := getopt.New("free")
+	opts.Bool(&config.Help, 0, "help")
+	opts.Bool(&config.Version, 'v', "version")
+	opts.Func('b', "bytes", getopt.NoArgument, unit(1, "B"))
+	opts.Func('k', "kibi", getopt.NoArgument, unit(KB, "KiB"))
+	opts.Func('m', "mebi", getopt.NoArgument, unit(MB, "MiB"))
+	opts.Func('g', "gibi", getopt.NoArgument, unit(GB, "GiB"))
+	opts.Func(0, "tebi", getopt.NoArgument, unit(TB, "TiB"))
+	opts.Func(0, "kilo", getopt.NoArgument, unit(1000, "kB"))
+	opts.Func(0, "mega", getopt.NoArgument, unit(1000*1000, "MB"))
+	opts.Func(0, "giga", getopt.NoArgument, unit(1000*1000*1000, "GB"))
+	opts.Func('h', "human", getopt.NoArgument, func(string) error {
+		config.Human = 1024
+		return nil
+	})
+	opts.Bool(&config.SI, 0, "si")
+	opts.Bool(&config.Wide, 'w', "wide")
+	opts.Bool(&config.Total, 't', "total")
+	opts.Func('s', "seconds", getopt.RequiredArgument, func(value string) error {
+		seconds, err := strconv.ParseFloat(value, 64)
+		if err != nil || seconds <= 0 {
+			return fmt.Errorf("неверный интервал '%s'", value)
+		}
+		config.Interval = time.Duration(seconds * float64(time.Second))
+		return nil
+	})
+	opts.Func('c', "count", getopt.RequiredArgument, func(value string) error {
+		count, err := strconv.Atoi(value)
+		if err != nil || count <= 0 {
+			return fmt.Errorf("неверное число повторов '%s'", value)
+		}
+		config.Count = count
+		return nil
+	})
+	args := opts.Parse(os.Args[1:])
+
+	if len(args) > 0 {
+		opts.Failf("неизвестный аргумент '%s'", args[0])
+	}
+	if config.SI {
+		// --si переводит -h, -k, -m, -g в степени 1000, как в procps
+		if config.Human != 0 {
+			config.Human = 1000
+		} else {
+			switch config.Unit {
+			case KB:
+				config.Unit, config.UnitName = 1000, "kB"
+			case MB:
+				config.Unit, config.UnitName = 1000*1000, "MB"
+			case GB:
+				config.Unit, config.UnitName = 1000*1000*1000, "GB"
+			case TB:
+				config.Unit, config.UnitName = 1000*1000*1000*1000, "TB"
+			}
+		}
+	}
+	// -c без -s выводит раз в секунду
+	if config.Count > 0 && config.Interval == 0 {
+		config.Interval = time.Second
+	}
+
+	return config
+}
+
+// printHelp выводит справку
+func printHelp() {
+	fmt.Println("free - отображает информацию о памяти")
+	fmt.Println()
+	fmt.Println("Использование: free [ОПЦИЯ]...")
+	fmt.Println()
+	fmt.Println("Данные берутся из /proc/meminfo. used - память, которую нельзя освободить")
+	fmt.Println("без подкачки (total - available); кэш страниц и буферы в нее не входят и")
+	fmt.Println("показаны в buff/cache.")
+	fmt.Println()
+	fmt.Println("Опции:")
+	fmt.Println("  -b, --bytes        байты")
+	fmt.Println("  -k, --kibi         кибибайты (по умолчанию)")
+	fmt.Println("  -m, --mebi         мебибайты")
+	fmt.Println("  -g, --gibi         гибибайты")
+	fmt.Println("      --tebi         тебибайты")
+	fmt.Println("      --kilo, --mega, --giga")
+	fmt.Println("                     килобайты, мегабайты, гигабайты (степени 1000)")
+	fmt.Println("  -h, --human        удобный вид: 1.5Gi, 300Mi; с --si - 1.5G, 300M")
+	fmt.Println("      --si           степени 1000 вместо 1024")
+	fmt.Println("  -w, --wide         buffers и cache в отдельных столбцах")
+	fmt.Println("  -t, --total        строка с суммой памяти и подкачки")
+	fmt.Println("  -s, --seconds=N    повторять вывод каждые N секунд (можно дробное)")
+	fmt.Println("  -c, --count=M      вывести M раз (с -s; без него - раз в секунду)")
+	fmt.Println("      --help         показать эту справку")
+	fmt.Println("  -v, --version      показать информацию о версии")
+	fmt.Println()
+	fmt.Println("Примеры:")
+	fmt.Println("  free                    # В кибибайтах")
+	fmt.Println("  free -h                 # В удобном виде")
+	fmt.Println("  free -m -t              # В мебибайтах с итогом")
+	fmt.Println("  free -s 2 -c 5          # Пять выводов с интервалом 2 секунды")
+}
+
+// printVersion выводит информацию о версии
+func printVersion() {
+	fmt.Println("free версия", ver)
+	fmt.Println("Разработано в рамках учебного проекта")
+	fmt.Println("Язык программирования: Golang")
+}
+
+// memInfo - значения из /proc/meminfo в байтах
+type memInfo struct {
+	Total, Free, Available, Shared, Buffers, Cache uint64
+	SwapTotal, SwapFree                            uint64
+}
+
+// readMemInfo читает /proc/meminfo. Значения там в килобайтах (на
+// самом деле в KiB, несмотря на подпись "kB").
+func readMemInfo() (*memInfo, error) {
+	f, err := os.Open("/proc/meminfo")
+	if err != nil {
+		return nil, fmt.Errorf("не удается прочитать /proc/meminfo: %v", err)
+	}
+	defer f.Close()
+
+	values := map[string]uint64{}
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		name, rest, found := strings.Cut(scanner.Text(), ":")
+		if !found {
+			continue
+		}
+		fields := strings.Fields(rest)
+		if len(fields) == 0 {
+			continue
+		}
+		n, err := strconv.ParseUint(fields[0], 10, 64)
+		if err != nil {
+			continue
+		}
+		if len(fields) > 1 && fields[1] == "kB" {
+			n *= KB
+		}
+		values[name] = n
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if _, ok := values["MemTotal"]; !ok {
+		return nil, fmt.Errorf("в /proc/meminfo нет MemTotal")
+	}
+
+	m := &memInfo{
+		Total:     values["MemTotal"],
+		Free:      values["MemFree"],
+		Shared:    values["Shmem"],
+		Buffers:   values["Buffers"],
+		Cache:     values["Cached"] + values["SReclaimable"],
+		SwapTotal: values["SwapTotal"],
+		SwapFree:  values["SwapFree"],
+	}
+	if available, ok := values["MemAvailable"]; ok {
+		m.Available = min(available, m.Total)
+	} else {
+		// Ядра до 3.14 не сообщают MemAvailable
+		m.Available = min(m.Free+m.Buffers+m.Cache, m.Total)
+	}
+	return m, nil
+}
+
+// used возвращает занятую память: все, что нельзя освободить без подкачки
+func (m *memInfo) used() uint64 {
+	return m.Total - m.Available
+}
+
+// format переводит байты в единицы вывода
+func (config *Config) format(bytes uint64) string {
+	if config.Human != 0 {
+		return humanSize(bytes, config.Human)
+	}
+	return strconv.FormatUint(bytes/config.Unit, 10)
+}
+
+// humanSize форматирует байты, как procps free -h: берется первая единица,
+// в которой число с одним знаком после запятой (округленное до ближайшего)
+// или, если оно длинное, целая часть помещается в 4 символа: 0B, 9.4M,
+// 719M, 6.3G. Двоичные единицы подписываются Ki, Mi, Gi, и им отводится
+// 5 символов.
+func humanSize(bytes uint64, base int) string {
+	if bytes < 1000 {
+		return strconv.FormatUint(bytes, 10) + "B"
+	}
+
+	limit, suffix := 4, ""
+	if base == 1024 {
+		limit, suffix = 5, "i"
+	}
+	value := float64(bytes)
+	for _, unit := range "KMGTP" {
+		value /= float64(base)
+		// procps выводит частное в одинарной точности
+		if s := fmt.Sprintf("%.1f%c%s", float32(value), unit, suffix); len(s) <= limit {
+			return s
+		}
+		if s := fmt.Sprintf("%d%c%s", int64(value), unit, suffix); len(s) <= limit {
+			return s
+		}
+	}
+	return fmt.Sprintf("%d%c%s", int64(value), 'P', suffix)
+}
+
+// printTable выводит таблицу памяти в формате procps free
+func printTable(config *Config, m *memInfo) {
+	label := ""
+	if config.Human == 0 {
+		label = config.UnitName
+	}
+
+	header := []string{"total", "used", "free", "shared", "buff/cache", "available"}
+	mem := []uint64{m.Total, m.used(), m.Free, m.Shared, m.Buffers + m.Cache, m.Available}
+	if config.Wide {
+		header = []string{"total", "used", "free", "shared", "buffers", "cache", "available"}
+		mem = []uint64{m.Total, m.used(), m.Free, m.Shared, m.Buffers, m.Cache, m.Available}
+	}
+	swapUsed := m.SwapTotal - min(m.SwapFree, m.SwapTotal)
+	swap := []uint64{m.SwapTotal, swapUsed, m.SwapFree}
+
+	w := bufio.NewWriter(os.Stdout)
+	defer w.Flush()
+
+	row := func(name string, cells []string) {
+		fmt.Fprintf(w, "%-8s", name)
+		for _, cell := range cells {
+			fmt.Fprintf(w, "%12s", cell)
+		}
+		fmt.Fprintln(w)
+	}
+	values := func(name string, numbers []uint64) {
+		cells := make([]string, len(numbers))
+		for i, n := range numbers {
+			cells[i] = config.format(n)
+		}
+		row(name, cells)
+	}
+
+	row(label, header)
+	values("Mem:", mem)
+	values("Swap:", swap)
+	if config.Total {
+		values("Total:", []uint64{m.Total + m.SwapTotal, m.used() + swapUsed, m.Free + m.SwapFree})
+	}
+}
+
+// executeFree выводит таблицу один раз или повторяет ее с -s и -c
+func executeFree(config *Config) error {
+	for i := 1; ; i++ {
+		m, err := readMemInfo()
+		if err != nil {
+			return err
+		}
+		printTable(config, m)
+
+		if config.Interval == 0 || (config.Count > 0 && i >= config.Count) {
+			return nil
+		}
+		fmt.Println()
+		time.Sleep(config.Interval)
+	}
+}
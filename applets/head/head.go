@@ -0,0 +1,449 @@
+package head
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/mir-yks/LinuxCommandAnalog/internal/applet"
+	"github.com/mir-yks/LinuxCommandAnalog/internal/getopt"
+	"github.com/mir-yks/LinuxCommandAnalog/internal/input"
+)
+
+type Config struct {
+	Help      bool
+	Version   bool
+	Count     int64 // сколько строк или байт выводить
+	Bytes     bool  // считать байты (-c), а не строки (-n)
+	AllBut    bool  // -K: выводить все, кроме последних K строк или байт
+	Quiet     bool
+	Verbose   bool
+	Zero      bool // -z: записи разделяются NUL, а не переводом строки
+	Filenames []string
+}
+
+const ver = "1.0.0"
+
+// blockSize - размер блока, которыми файл читается с конца
+const blockSize = 64 * 1024
+
+func init() {
+	applet.Register("head", Main)
+}
+
+// Main запускает утилиту head
+func Main() {
+	defer func() {
+		if r := recover(); r != nil {
+			fmt.Fprintf(os.Stderr, "Ошибка: %v\n", r)
+			os.Exit(1)
+		}
+	}()
+
+	config := parseArgs()
+
+	if config.Help {
+		printHelp()
+		return
+	}
+
+	if config.Version {
+		printVersion()
+		return
+	}
+
+	if !executeHead(config) {
+		os.Exit(1)
+	}
+}
+
+// parseArgs разбирает аргументы командной строки
+func parseArgs() *Config {
+	config := &Config{Count: 10}
+
+	opts := getopt.New("head")
+	opts.Bool(&config.Help, 'h', "help")
+	opts.Bool(&config.Version, 0, "version")
+	opts.Func('c', "bytes", getopt.RequiredArgument, func(value string) error {
+		config.Bytes = true
+		return parseCount(value, config)
+	})
+	opts.Func('n', "lines", getopt.RequiredArgument, func(value string) error {
+		config.Bytes = false
+		return parseCount(value, config)
+	})
+	// Из -q и -v действует последний
+	quiet := func(string) error {
+		config.Quiet, config.Verbose = true, false
+		return nil
+	}
+	opts.Func('q', "quiet", getopt.NoArgument, quiet)
+	opts.Func(0, "silent", getopt.NoArgument, quiet)
+	opts.Func('v', "verbose", getopt.NoArgument, func(string) error {
+		config.Quiet, config.Verbose = false, true
+		return nil
+	})
+	opts.Bool(&config.Zero, 'z', "zero-terminated")
+	config.Filenames = input.Operands(opts.Parse(os.Args[1:]))
+
+	return config
+}
+
+// parseCount разбирает значение -n/-c: K - первые K, -K - все, кроме
+// последних K. Допускаются суффиксы b (512), K, M, G... (степени 1024),
+// KB, MB, GB... (степени 1000) и KiB, MiB, GiB... (то же, что K, M, G).
+func parseCount(value string, config *Config) error {
+	config.AllBut = strings.HasPrefix(value, "-")
+	count, err := parseSize(strings.TrimPrefix(value, "-"))
+	if err != nil {
+		return fmt.Errorf("неверное количество '%s'", value)
+	}
+	config.Count = count
+	return nil
+}
+
+// parseSize разбирает неотрицательное число с необязательным суффиксом
+// размера. Слишком большое число ограничивается максимальным: «все».
+func parseSize(value string) (int64, error) {
+	digits := strings.TrimRightFunc(value, func(r rune) bool { return r < '0' || r > '9' })
+	suffix := value[len(digits):]
+	num, err := strconv.ParseInt(digits, 10, 64)
+	if err != nil && !errorIsRange(err) || num < 0 {
+		return 0, strconv.ErrSyntax
+	}
+	if err != nil {
+		num = math.MaxInt64
+	}
+	if suffix == "" {
+		return num, nil
+	}
+	if suffix == "b" {
+		return saturatingMul(num, 512), nil
+	}
+
+	base := int64(1024)
+	switch suffix[1:] {
+	case "", "iB":
+	case "B":
+		base = 1000
+	default:
+		return 0, strconv.ErrSyntax
+	}
+	// Строчными допускаются только k и m, как в GNU head
+	unit := suffix[:1]
+	if unit == "k" || unit == "m" {
+		unit = strings.ToUpper(unit)
+	}
+	power := strings.Index("KMGTPE", unit)
+	if power < 0 {
+		return 0, strconv.ErrSyntax
+	}
+	for i := 0; i <= power; i++ {
+		num = saturatingMul(num, base)
+	}
+	return num, nil
+}
+
+// errorIsRange сообщает, что число не помещается в int64
+func errorIsRange(err error) bool {
+	numErr, ok := err.(*strconv.NumError)
+	return ok && numErr.Err == strconv.ErrRange
+}
+
+// saturatingMul перемножает неотрицательные числа, не переполняясь
+func saturatingMul(a, b int64) int64 {
+	if a != 0 && b > math.MaxInt64/a {
+		return math.MaxInt64
+	}
+	return a * b
+}
+
+// printHelp выводит справку
+func printHelp() {
+	fmt.Println("head - выводит начало каждого заданного файла")
+	fmt.Println()
+	fmt.Println("Использование: head [ОПЦИЯ]... [ФАЙЛ]...")
+	fmt.Println()
+	fmt.Println("Опции:")
+	fmt.Println("  -c, --bytes=[-]N        выводить первые N байт; -N - все, кроме последних N")
+	fmt.Println("  -n, --lines=[-]N        выводить первые N строк; -N - все, кроме последних N")
+	fmt.Println("  -q, --quiet, --silent   никогда не выводить заголовки с именами файлов")
+	fmt.Println("  -v, --verbose           всегда выводить заголовки с именами файлов")
+	fmt.Println("  -z, --zero-terminated   строки разделяются NUL, а не переводом строки")
+	fmt.Println("  -h, --help              показать эту справку")
+	fmt.Println("  --version               показать информацию о версии")
+	fmt.Println()
+	fmt.Println("По умолчанию N=10 строк. После N можно указать суффикс: b (512),")
+	fmt.Println("K, M, G, T, P, E (степени 1024), KB, MB, ... (степени 1000) или")
+	fmt.Println("KiB, MiB, ... (то же, что K, M). Заголовки \"==> ФАЙЛ <==\" выводятся,")
+	fmt.Println("если файлов несколько. Если ФАЙЛ не задан или задан как -,")
+	fmt.Println("читается стандартный ввод.")
+	fmt.Println()
+	fmt.Println("Примеры:")
+	fmt.Println("  head file.txt              # Первые 10 строк")
+	fmt.Println("  head -n 5 file.txt         # Первые 5 строк")
+	fmt.Println("  head -n -1 file.txt        # Все строки, кроме последней")
+	fmt.Println("  head -c 1K file.bin        # Первые 1024 байта")
+	fmt.Println("  head -q file1.txt file2.txt # Без заголовков")
+}
+
+// printVersion выводит информацию о версии
+func printVersion() {
+	fmt.Println("head версия", ver)
+	fmt.Println("Разработано в рамках учебного проекта")
+	fmt.Println("Язык программирования: Golang")
+}
+
+// executeHead выводит начало каждого файла. Возвращает false, если хотя
+// бы один файл не удалось обработать.
+func executeHead(config *Config) bool {
+	out := bufio.NewWriter(os.Stdout)
+	defer out.Flush()
+
+	headers := config.Verbose || (len(config.Filenames) > 1 && !config.Quiet)
+	ok := true
+	started := false
+
+	for _, name := range config.Filenames {
+		file, err := input.Open(name)
+		if err != nil {
+			out.Flush()
+			fmt.Fprintf(os.Stderr, "head: %v\n", input.OpenError(name, err))
+			ok = false
+			continue
+		}
+
+		if headers {
+			if started {
+				out.WriteString("\n")
+			}
+			title := name
+			if file.IsStdin() {
+				title = "стандартный ввод"
+			}
+			fmt.Fprintf(out, "==> %s <==\n", title)
+		}
+		started = true
+
+		err = printHead(out, file, config)
+		flushErr := out.Flush()
+		switch {
+		case err != nil:
+			fmt.Fprintf(os.Stderr, "head: %v\n", input.ReadError(name, err))
+			ok = false
+		case flushErr != nil:
+			fmt.Fprintf(os.Stderr, "head: ошибка записи: %v\n", flushErr)
+			ok = false
+		}
+		file.Close()
+	}
+	return ok
+}
+
+// printHead выводит начало одного файла в выбранном режиме
+func printHead(out *bufio.Writer, file *input.File, config *Config) error {
+	delim := byte('\n')
+	if config.Zero {
+		delim = 0
+	}
+
+	switch {
+	case config.Bytes && config.AllBut:
+		return copyAllButBytes(out, file, config.Count)
+	case config.Bytes:
+		// CopyN читает блоками, поэтому большой N не требует большого буфера
+		if _, err := io.CopyN(out, file, config.Count); err != nil && err != io.EOF {
+			return err
+		}
+		return nil
+	case config.AllBut:
+		return copyAllButLines(out, file, config.Count, delim)
+	default:
+		return copyLines(out, file, config.Count, delim)
+	}
+}
+
+// copyLines выводит первые lineCount строк. Если ввод можно перемотать,
+// он остается сразу за последней выведенной строкой, чтобы следующая
+// программа продолжила чтение с этого места.
+func copyLines(out *bufio.Writer, file *input.File, lineCount int64, delim byte) error {
+	if lineCount == 0 {
+		return nil
+	}
+	reader := bufio.NewReader(file)
+
+	for line := int64(0); line < lineCount; {
+		chunk, err := reader.ReadSlice(delim)
+		out.Write(chunk)
+		switch err {
+		case nil:
+			line++
+		case bufio.ErrBufferFull:
+			// Строка длиннее буфера: выводим ее по частям
+		case io.EOF:
+			return nil
+		default:
+			return err
+		}
+	}
+
+	if file.Seekable() && reader.Buffered() > 0 {
+		file.Seek(-int64(reader.Buffered()), io.SeekCurrent)
+	}
+	return nil
+}
+
+// copyAllButLines выводит все строки, кроме последних lineCount
+func copyAllButLines(out *bufio.Writer, file *input.File, lineCount int64, delim byte) error {
+	start, size, ok := extent(file)
+	if !ok {
+		return delayLines(out, file, lineCount, delim)
+	}
+	if size <= start {
+		return nil
+	}
+
+	end, err := findLinesEnd(file, start, size, lineCount, delim)
+	if err != nil {
+		return err
+	}
+	if _, err := io.CopyN(out, file, end-start); err != nil && err != io.EOF {
+		return err
+	}
+	return nil
+}
+
+// extent возвращает текущую позицию и размер файла, если его конец можно
+// найти без чтения. У каналов и файлов /proc и /sys с нулевым размером
+// ok = false: их читаем как поток.
+func extent(file *input.File) (start, size int64, ok bool) {
+	if !file.Seekable() {
+		return 0, 0, false
+	}
+	info, err := file.Stat()
+	if err != nil || info.Size() == 0 {
+		return 0, 0, false
+	}
+	start, err = file.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return 0, 0, false
+	}
+	return start, info.Size(), true
+}
+
+// findLinesEnd возвращает смещение конца данных без последних lineCount
+// строк на участке [start, size). Файл читается блоками с конца.
+func findLinesEnd(file *input.File, start, size, lineCount int64, delim byte) (int64, error) {
+	if lineCount == 0 {
+		return size, nil
+	}
+	buffer := make([]byte, blockSize)
+	end := size
+	found := int64(0)
+
+	// Разделитель в самом конце завершает последнюю строку, а не начинает
+	// новую
+	last := buffer[:1]
+	if _, err := file.ReadAt(last, size-1); err != nil {
+		return 0, err
+	}
+	if last[0] == delim {
+		end--
+	}
+
+	for end > start {
+		from := max(end-blockSize, start)
+		block := buffer[:end-from]
+		if _, err := file.ReadAt(block, from); err != nil && err != io.EOF {
+			return 0, err
+		}
+
+		for i := len(block) - 1; i >= 0; i-- {
+			if block[i] != delim {
+				continue
+			}
+			found++
+			if found == lineCount {
+				return from + int64(i) + 1, nil
+			}
+		}
+		end = from
+	}
+
+	return start, nil
+}
+
+// delayLines выводит поток без последних lineCount строк: в памяти
+// хранятся только строки, которые еще могут оказаться последними
+func delayLines(out *bufio.Writer, r io.Reader, lineCount int64, delim byte) error {
+	if lineCount == 0 {
+		if _, err := io.Copy(out, r); err != nil {
+			return err
+		}
+		return nil
+	}
+
+	reader := bufio.NewReader(r)
+	ring := make([][]byte, 0, min(lineCount, 1024))
+	next := 0
+
+	for {
+		line, err := reader.ReadBytes(delim)
+		if len(line) > 0 {
+			if int64(len(ring)) < lineCount {
+				ring = append(ring, line)
+			} else {
+				out.Write(ring[next])
+				ring[next] = line
+				next = (next + 1) % len(ring)
+			}
+		}
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// copyAllButBytes выводит все, кроме последних byteCount байт
+func copyAllButBytes(out *bufio.Writer, file *input.File, byteCount int64) error {
+	start, size, ok := extent(file)
+	if !ok {
+		return delayBytes(out, file, byteCount)
+	}
+	if size-start <= byteCount {
+		return nil
+	}
+	if _, err := io.CopyN(out, file, size-start-byteCount); err != nil && err != io.EOF {
+		return err
+	}
+	return nil
+}
+
+// delayBytes выводит поток без последних byteCount байт. Буфер растет по
+// мере чтения и не превышает byteCount плюс один блок.
+func delayBytes(out *bufio.Writer, r io.Reader, byteCount int64) error {
+	var pending []byte
+	block := make([]byte, blockSize)
+
+	for {
+		n, err := r.Read(block)
+		pending = append(pending, block[:n]...)
+		if extra := int64(len(pending)) - byteCount; extra > 0 {
+			out.Write(pending[:extra])
+			pending = pending[:copy(pending, pending[extra:])]
+		}
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
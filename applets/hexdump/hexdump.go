@@ -0,0 +1,173 @@
+package hexdump
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+
+	"github.com/mir-yks/LinuxCommandAnalog/internal/applet"
+	"github.com/mir-yks/LinuxCommandAnalog/internal/getopt"
+	"github.com/mir-yks/LinuxCommandAnalog/internal/input"
+)
+
+func init() {
+	applet.Register("hexdump", Main)
+}
+
+// Main запускает утилиту hexdump
+func Main() {
+	var canonical, help bool
+	var length, skip uint64
+
+	opts := getopt.New("hexdump")
+	opts.Bool(&canonical, 'C', "canonical")
+	opts.Func('n', "length", getopt.RequiredArgument, parseCount(&length))
+	opts.Func('s', "skip", getopt.RequiredArgument, parseCount(&skip))
+	opts.Bool(&help, 'h', "help")
+	args := opts.Parse(os.Args[1:])
+
+	if help { 
+		showHelp()
+		return 
+	}
+
+	ok := true
+	for _, filename := range input.Operands(args) {
+		if err := hexdumpFile(filename, canonical, length, skip); err != nil {
+			fmt.Fprintf(os.Stderr, "hexdump: %v\n", err)
+			ok = false
+			continue
+		}
+		fmt.Println()
+	}
+	if !ok {
+		os.Exit(1)
+	}
+}
+
+// parseCount возвращает обработчик ключа с неотрицательным числом байт
+func parseCount(p *uint64) func(string) error {
+	return func(value string) error {
+		n, err := strconv.ParseUint(value, 10, 64)
+		if err != nil {
+			return fmt.Errorf("неверное число байт '%s'", value)
+		}
+		*p = n
+		return nil
+	}
+}
+
+func hexdumpFile(filename string, canonical bool, maxLen, skip uint64) error {
+	f, err := input.Open(filename)
+	if err != nil { 
+		return input.OpenError(filename, err)
+	}
+	defer f.Close()
+
+	if skip > 0 { 
+		if err := skipBytes(f, skip); err != nil {
+			return input.ReadError(filename, err)
+		}
+	}
+
+	var r io.Reader = f
+	if maxLen > 0 {
+		r = io.LimitReader(f, int64(maxLen))
+	}
+
+	totalBytes := uint64(0)
+	buf := make([]byte, 16)
+	
+	for {
+		// Канал отдает данные частями, а строке дампа нужны все 16 байт
+		n, err := io.ReadFull(r, buf)
+		if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+			return input.ReadError(filename, err)
+		}
+		if n == 0 { 
+			break 
+		}
+		
+		lineOffset := totalBytes / 16
+		printLine(buf[:n], canonical, lineOffset)
+		totalBytes += uint64(n)
+		
+		if maxLen > 0 && totalBytes >= maxLen { 
+			break 
+		}
+	}
+
+	return nil
+}
+
+// skipBytes пропускает первые n байт: в файле перемещением, в канале чтением
+func skipBytes(f *input.File, n uint64) error {
+	if f.Seekable() {
+		_, err := f.Seek(int64(n), io.SeekStart)
+		return err
+	}
+	_, err := io.CopyN(io.Discard, f, int64(n))
+	if err == io.EOF {
+		return nil
+	}
+	return err
+}
+
+func printLine(data []byte, canonical bool, lineOffset uint64) {
+	if canonical {
+		fmt.Printf("%08x  ", lineOffset*16)
+		
+		for i := 0; i < len(data); i++ {
+			fmt.Printf("%02x ", data[i])
+		}
+		for i := len(data); i < 16; i++ {
+			fmt.Print("   ")
+		}
+		
+		fmt.Print("  |")
+		for _, b := range data {
+			if b >= 32 && b <= 126 {
+				fmt.Printf("%c", b)
+			} else {
+				fmt.Print(".")
+			}
+		}
+		for i := len(data); i < 16; i++ {
+			fmt.Print(" ")
+		}
+		fmt.Println("|")
+	} else {
+		fmt.Printf("%07x ", lineOffset*16)
+		for i := 0; i < len(data); i += 2 {
+			if i+1 < len(data) {
+				val := uint16(data[i]) | uint16(data[i+1])<<8
+				fmt.Printf("%04x ", val)
+			} else {
+				fmt.Printf("%02x ", data[i])
+			}
+		}
+		fmt.Println()
+	}
+}
+
+func showHelp() {
+	fmt.Println("hexdump - отображает содержимое файла в шестнадцатеричном виде")
+	fmt.Println()
+	fmt.Println("Использование: hexdump [ОПЦИЯ]... [ФАЙЛ]...")
+	fmt.Println()
+	fmt.Println("Если ФАЙЛ не задан или задан как -, читается стандартный ввод.")
+	fmt.Println()
+	fmt.Println("Опции:")
+	fmt.Println("  -C     канонический формат")
+	fmt.Println("  -n N   читать N байт")
+	fmt.Println("  -s N   пропустить N байт")
+	fmt.Println("  -h     показать эту справку")
+	fmt.Println()
+	fmt.Println("Примеры:")
+	fmt.Println("  hexdump test.bin")
+	fmt.Println("  hexdump -C test.bin              # Канонический формат")
+	fmt.Println("  hexdump -s 64 test.bin           # Пропустить первые 64 байта")
+	fmt.Println("  hexdump -n 32 test.bin           # Показать первые 32 байта")
+}
+
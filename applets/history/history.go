@@ -1,4 +1,4 @@
-package main
+package history
 
 import (
 	"bufio"
@@ -7,6 +7,9 @@ import (
 	"path/filepath"
 	"strconv"
 	"strings"
+
+	"github.com/mir-yks/LinuxCommandAnalog/internal/applet"
+	"github.com/mir-yks/LinuxCommandAnalog/internal/getopt"
 )
 
 type Config struct {
@@ -16,7 +19,12 @@ type Config struct {
 	Help       bool
 }
 
-func main() {
+func init() {
+	applet.Register("history", Main)
+}
+
+// Main запускает утилиту history
+func Main() {
 	defer func() {
 		if r := recover(); r != nil {
 			fmt.Fprintf(os.Stderr, "Ошибка: %v\n", r)
@@ -58,70 +66,33 @@ func main() {
 
 func parseArgs() *Config {
 	config := &Config{}
-	i := 1
-
-	for i < len(os.Args) {
-		arg := os.Args[i]
-
-		switch arg {
-		case "-h":
-			config.Help = true
-			i++
-			continue
-		case "-c":
-			config.ClearHist = true
-			i++
-			continue
-		case "-d":
-			i++
-			if i >= len(os.Args) {
-				panic("history: ожидается номер строки после -d")
-			}
-			num, err := strconv.Atoi(os.Args[i])
-			if err != nil || num < 1 {
-				panic("history: номер строки после -d должен быть положительным числом")
-			}
-			config.DeleteLine = num
-			i++
-			continue
-		case "-n":
-			i++
-			if i >= len(os.Args) {
-				panic("history: ожидается число после -n")
-			}
-			num, err := strconv.Atoi(os.Args[i])
-			if err != nil || num < 1 {
-				panic("history: число после -n должно быть положительным")
-			}
-			config.NumLines = num
-			i++
-			continue
-		default:
-			if len(arg) > 1 && arg[0] == '-' {
-				for _, ch := range arg[1:] {
-					switch ch {
-					case 'h':
-						config.Help = true
-					case 'c':
-						config.ClearHist = true
-					case 'd':
-						panic("history: неверный формат -d (ожидается -d N)")
-					case 'n':
-						panic("history: неверный формат -n (ожидается -n N)")
-					default:
-						panic(fmt.Sprintf("history: неверный ключ — '%s'", arg))
-					}
-				}
-				i++
-				continue
-			}
-			panic(fmt.Sprintf("history: неизвестный аргумент '%s'", arg))
-		}
+
+	opts := getopt.New("history")
+	opts.Bool(&config.Help, 'h', "help")
+	opts.Bool(&config.ClearHist, 'c', "")
+	opts.Func('d', "", getopt.RequiredArgument, positiveInt(&config.DeleteLine))
+	opts.Func('n', "", getopt.RequiredArgument, positiveInt(&config.NumLines))
+	args := opts.Parse(os.Args[1:])
+
+	if len(args) > 0 {
+		opts.Failf("неизвестный аргумент '%s'", args[0])
 	}
 
 	return config
 }
 
+// positiveInt возвращает обработчик ключа с положительным числом
+func positiveInt(p *int) func(string) error {
+	return func(value string) error {
+		num, err := strconv.Atoi(value)
+		if err != nil || num < 1 {
+			return fmt.Errorf("ожидается положительное число, получено '%s'", value)
+		}
+		*p = num
+		return nil
+	}
+}
+
 func printHelp() {
 	fmt.Println("history - просмотр и управление историей команд bash")
 	fmt.Println()
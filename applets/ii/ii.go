@@ -1,4 +1,4 @@
-package main
+package ii
 
 import (
 	"bufio"
@@ -7,16 +7,25 @@ import (
 	"os/exec"
 	"path/filepath"
 	"strings"
+
+	"github.com/mir-yks/LinuxCommandAnalog/internal/applet"
+	"github.com/mir-yks/LinuxCommandAnalog/internal/getopt"
 )
 
 type Config struct {
 	Help    bool
 	Version bool
+	Args    []string
 }
 
 const ver = "1.0.0"
 
-func main() {
+func init() {
+	applet.Register("ii", Main)
+}
+
+// Main запускает утилиту ii
+func Main() {
 	defer func() {
 		if r := recover(); r != nil {
 			fmt.Fprintf(os.Stderr, "!!: %v\n", r)
@@ -43,7 +52,11 @@ func main() {
 	}
 	histFile := filepath.Join(homeDir, ".bash_history")
 
-	history := readHistoryFromFile(histFile)
+	history, err := readHistoryFromFile(histFile)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "!!: %v\n", err)
+		os.Exit(1)
+	}
 	if len(history) == 0 {
 		fmt.Fprintln(os.Stderr, "!!: история пуста")
 		os.Exit(1)
@@ -56,7 +69,7 @@ func main() {
 		os.Exit(1)
 	}
 
-	finalArgs := append([]string{parts[0]}, os.Args[1:]...)
+	finalArgs := append([]string{parts[0]}, config.Args...)
 
 	fmt.Printf("%s\n", strings.Join(finalArgs, " "))
 
@@ -73,20 +86,15 @@ func main() {
 
 func parseArgs() *Config {
 	config := &Config{}
-	
-	for i := 1; i < len(os.Args); i++ {
-		arg := os.Args[i]
-		
-		switch arg {
-		case "-h":
-			config.Help = true
-			return config
-		case "-v", "--version":
-			config.Version = true
-			return config
-		}
-	}
-	
+
+	// Чужие ключи относятся к повторяемой команде и передаются ей как есть
+	opts := getopt.New("ii")
+	opts.Bool(&config.Help, 'h', "help")
+	opts.Bool(&config.Version, 'v', "version")
+	opts.StopAtOperand()
+	opts.PassUnknown()
+	config.Args = opts.Parse(os.Args[1:])
+
 	return config
 }
 
@@ -110,10 +118,10 @@ func printVersion() {
 	fmt.Println("Язык программирования: Golang")
 }
 
-func readHistoryFromFile(histFile string) []string {
+func readHistoryFromFile(histFile string) ([]string, error) {
 	file, err := os.Open(histFile)
 	if err != nil {
-		return nil
+		return nil, nil
 	}
 	defer file.Close()
 
@@ -126,8 +134,8 @@ func readHistoryFromFile(histFile string) []string {
 		}
 	}
 	if err := scanner.Err(); err != nil {
-		panic(fmt.Sprintf("ошибка чтения истории: %v", err))
+		return nil, fmt.Errorf("ошибка чтения истории: %v", err)
 	}
-	return lines
+	return lines, nil
 }
 
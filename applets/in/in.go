@@ -1,4 +1,4 @@
-package main
+package in
 
 import (
 	"bufio"
@@ -8,17 +8,26 @@ import (
 	"path/filepath"
 	"strconv"
 	"strings"
+
+	"github.com/mir-yks/LinuxCommandAnalog/internal/applet"
+	"github.com/mir-yks/LinuxCommandAnalog/internal/getopt"
 )
 
 type Config struct {
 	Help    bool
 	Version bool
 	Number  int
+	Args    []string
 }
 
 const ver = "1.0.0"
 
-func main() {
+func init() {
+	applet.Register("in", Main)
+}
+
+// Main запускает утилиту in
+func Main() {
 	defer func() {
 		if r := recover(); r != nil {
 			fmt.Fprintf(os.Stderr, "!n: %v\n", r)
@@ -45,7 +54,11 @@ func main() {
 	}
 	histFile := filepath.Join(homeDir, ".bash_history")
 
-	history := readHistoryFromFile(histFile)
+	history, err := readHistoryFromFile(histFile)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "!n: %v\n", err)
+		os.Exit(1)
+	}
 	if len(history) == 0 {
 		fmt.Fprintln(os.Stderr, "!n: история пуста")
 		os.Exit(1)
@@ -75,9 +88,7 @@ func main() {
 		os.Exit(1)
 	}
 
-	finalArgs := parts
-	userArgs := getUserArgs(config)
-	finalArgs = append(finalArgs, userArgs...)
+	finalArgs := append(parts, config.Args...)
 
 	fmt.Printf("%s\n", strings.Join(finalArgs, " "))
 
@@ -92,46 +103,27 @@ func main() {
 	}
 }
 
-func getUserArgs(config *Config) []string {
-	var args []string
-	for i := 1; i < len(os.Args); i++ {
-		arg := os.Args[i]
-		if arg == "-h" || arg == "-v" || arg == "--version" {
-			continue
-		}
-		if config.Number > 0 && isNumberArg(arg) {
-			continue
-		}
-		args = append(args, arg)
-	}
-	return args
-}
-
-func isNumberArg(arg string) bool {
-	num, err := strconv.Atoi(arg)
-	return err == nil && num > 0
-}
-
 func parseArgs() *Config {
 	config := &Config{}
-	
-	for i := 1; i < len(os.Args); i++ {
-		arg := os.Args[i]
-		switch arg {
-		case "-h":
-			config.Help = true
-			return config
-		case "-v", "--version":
-			config.Version = true
-			return config
-		default:
-			num, err := strconv.Atoi(arg)
-			if err == nil && num > 0 {
-				config.Number = num
-				return config
+
+	// Чужие ключи относятся к повторяемой команде и передаются ей как есть
+	opts := getopt.New("in")
+	opts.Bool(&config.Help, 'h', "help")
+	opts.Bool(&config.Version, 'v', "version")
+	opts.StopAtOperand()
+	opts.PassUnknown()
+	config.Args = opts.Parse(os.Args[1:])
+
+	if len(config.Args) > 0 {
+		if num, err := strconv.Atoi(config.Args[0]); err == nil {
+			if num < 1 {
+				opts.Failf("номер команды должен быть положительным числом: '%s'", config.Args[0])
 			}
+			config.Number = num
+			config.Args = config.Args[1:]
 		}
 	}
+
 	return config
 }
 
@@ -156,10 +148,10 @@ func printVersion() {
 	fmt.Println("Язык программирования: Golang")
 }
 
-func readHistoryFromFile(histFile string) []string {
+func readHistoryFromFile(histFile string) ([]string, error) {
 	file, err := os.Open(histFile)
 	if err != nil {
-		return nil
+		return nil, nil
 	}
 	defer file.Close()
 
@@ -172,8 +164,8 @@ func readHistoryFromFile(histFile string) []string {
 		}
 	}
 	if err := scanner.Err(); err != nil {
-		panic(fmt.Sprintf("ошибка чтения истории: %v", err))
+		return nil, fmt.Errorf("ошибка чтения истории: %v", err)
 	}
-	return lines
+	return lines, nil
 }
 
@@ -0,0 +1,241 @@
+package kill
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"syscall"
+
+	"github.com/mir-yks/LinuxCommandAnalog/internal/applet"
+	"github.com/mir-yks/LinuxCommandAnalog/internal/getopt"
+	"github.com/mir-yks/LinuxCommandAnalog/internal/signals"
+)
+
+type Config struct {
+	Help     bool
+	Version  bool
+	List     bool           // -l: список сигналов или перевод номера в имя
+	Table    bool           // -L: таблица сигналов с номерами
+	Signal   syscall.Signal // сигнал для отправки; по умолчанию TERM
+	Operands []string       // PID или, с -l, сигналы для перевода
+}
+
+const ver = "1.0.0"
+
+// listWidth - ширина строки в kill -l, как в procps
+const listWidth = 80
+
+func init() {
+	applet.Register("kill", Main)
+}
+
+// Main запускает утилиту kill
+func Main() {
+	defer func() {
+		if r := recover(); r != nil {
+			fmt.Fprintf(os.Stderr, "Ошибка: %v\n", r)
+			os.Exit(1)
+		}
+	}()
+
+	config := parseArgs()
+
+	if config.Help {
+		printHelp()
+		return
+	}
+
+	if config.Version {
+		printVersion()
+		return
+	}
+
+	var ok bool
+	switch {
+	case config.Table:
+		ok = printTable()
+	case config.List:
+		ok = listSignals(config.Operands)
+	default:
+		ok = executeKill(config)
+	}
+	if !ok {
+		os.Exit(1)
+	}
+}
+
+// parseArgs разбирает аргументы командной строки. Сигнал можно задать
+// первым аргументом в виде -9, -KILL или -SIGKILL, или ключом -s. После
+// ключей отрицательные числа - это PID групп процессов, а не ключи.
+func parseArgs() *Config {
+	config := &Config{Signal: syscall.SIGTERM}
+	args := os.Args[1:]
+
+	if len(args) > 0 && len(args[0]) > 1 && args[0][0] == '-' && args[0][1] != '-' {
+		if sig, err := signals.Parse(args[0][1:]); err == nil {
+			config.Signal = sig
+			args = args[1:]
+		}
+	}
+
+	opts := getopt.New("kill")
+	opts.StopAtOperand()
+	opts.PassUnknown()
+	opts.Bool(&config.Help, 'h', "help")
+	opts.Bool(&config.Version, 'v', "version")
+	opts.Bool(&config.List, 'l', "list")
+	opts.Bool(&config.Table, 'L', "table")
+	opts.Func('s', "signal", getopt.RequiredArgument, func(value string) error {
+		sig, err := signals.Parse(value)
+		if err != nil {
+			return err
+		}
+		config.Signal = sig
+		return nil
+	})
+	config.Operands = opts.Parse(args)
+	if len(config.Operands) > 0 && config.Operands[0] == "--" {
+		config.Operands = config.Operands[1:]
+	}
+
+	if config.Help || config.Version || config.List || config.Table {
+		return config
+	}
+	if len(config.Operands) == 0 {
+		opts.Failf("пропущен PID процесса")
+	}
+	// Неизвестный ключ или сигнал не должен превратиться в PID группы
+	for _, operand := range config.Operands {
+		if _, err := strconv.Atoi(operand); err != nil && strings.HasPrefix(operand, "-") {
+			opts.Failf("неизвестный сигнал или ключ '%s'", operand)
+		}
+	}
+	return config
+}
+
+// printHelp выводит справку
+func printHelp() {
+	fmt.Println("kill - отправляет сигнал процессам")
+	fmt.Println()
+	fmt.Println("Использование: kill [-СИГНАЛ | -s СИГНАЛ] PID...")
+	fmt.Println("         или: kill -l [СИГНАЛ]...")
+	fmt.Println("         или: kill -L")
+	fmt.Println()
+	fmt.Println("СИГНАЛ - номер (9), имя (KILL) или имя с префиксом (SIGKILL); регистр не")
+	fmt.Println("важен. По умолчанию отправляется TERM. Отрицательный PID означает группу")
+	fmt.Println("процессов: сигнал получат все ее участники.")
+	fmt.Println()
+	fmt.Println("Опции:")
+	fmt.Println("  -СИГНАЛ                отправить СИГНАЛ: -9, -HUP, -SIGUSR1")
+	fmt.Println("  -s, --signal=СИГНАЛ    отправить СИГНАЛ")
+	fmt.Println("  -l, --list [СИГНАЛ]    список имен сигналов; с аргументом - перевести")
+	fmt.Println("                         номер в имя или имя в номер")
+	fmt.Println("  -L, --table            таблица сигналов с номерами")
+	fmt.Println("  -h, --help             показать эту справку")
+	fmt.Println("  -v, --version          показать информацию о версии")
+	fmt.Println()
+	fmt.Println("Код возврата: 0, если сигнал доставлен всем процессам, иначе 1.")
+	fmt.Println()
+	fmt.Println("Примеры:")
+	fmt.Println("  kill 1234                    # TERM процессу 1234")
+	fmt.Println("  kill -9 1234 5678            # KILL двум процессам")
+	fmt.Println("  kill -s HUP 1234             # Перечитать настройки")
+	fmt.Println("  kill -SIGUSR1 1234           # Сигнал по полному имени")
+	fmt.Println("  kill -TERM -- -1234          # Всей группе процессов 1234")
+	fmt.Println("  kill -l 15                   # Имя сигнала 15: TERM")
+}
+
+// printVersion выводит информацию о версии
+func printVersion() {
+	fmt.Println("kill версия", ver)
+	fmt.Println("Разработано в рамках учебного проекта")
+	fmt.Println("Язык программирования: Golang")
+}
+
+// executeKill отправляет сигнал всем PID; false - если хотя бы один не получил его
+func executeKill(config *Config) bool {
+	ok := true
+	for _, operand := range config.Operands {
+		pid, err := strconv.Atoi(operand)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "kill: неверный PID '%s'\n", operand)
+			ok = false
+			continue
+		}
+		if err := syscall.Kill(pid, config.Signal); err != nil {
+			fmt.Fprintf(os.Stderr, "kill: не удалось отправить сигнал %s процессу %d: %v\n",
+				signalName(config.Signal), pid, err)
+			ok = false
+		}
+	}
+	return ok
+}
+
+// signalName возвращает имя сигнала для сообщений; у сигнала 0 имени нет
+func signalName(sig syscall.Signal) string {
+	if name := signals.Name(sig); name != "" {
+		return name
+	}
+	return strconv.Itoa(int(sig))
+}
+
+// listSignals выводит имена всех сигналов или переводит каждый аргумент:
+// номер в имя, имя в номер. Номер больше 128 считается кодом возврата
+// процесса, убитого сигналом: kill -l $? после 143 выведет TERM.
+func listSignals(names []string) bool {
+	if len(names) == 0 {
+		var line strings.Builder
+		for _, s := range signals.List() {
+			if line.Len() > 0 && line.Len()+1+len(s.Name) > listWidth {
+				fmt.Println(line.String())
+				line.Reset()
+			}
+			if line.Len() > 0 {
+				line.WriteByte(' ')
+			}
+			line.WriteString(s.Name)
+		}
+		fmt.Println(line.String())
+		return true
+	}
+
+	ok := true
+	for _, name := range names {
+		if n, err := strconv.Atoi(name); err == nil {
+			if n > 128 {
+				n -= 128
+			}
+			if sigName := signals.Name(syscall.Signal(n)); sigName != "" {
+				fmt.Println(sigName)
+				continue
+			}
+		} else if sig, err := signals.Parse(name); err == nil {
+			fmt.Println(int(sig))
+			continue
+		}
+		fmt.Fprintf(os.Stderr, "kill: неизвестный сигнал '%s'\n", name)
+		ok = false
+	}
+	return ok
+}
+
+// printTable выводит таблицу сигналов по семь в строке. Столбец имени
+// шириной 8, как в procps, расширяется под длинные имена вроде RTMAX-14.
+func printTable() bool {
+	width := 8
+	for _, s := range signals.List() {
+		width = max(width, len(s.Name)+1)
+	}
+	for i, s := range signals.List() {
+		if i%7 == 6 {
+			fmt.Printf("%2d %s\n", int(s.Number), s.Name)
+		} else {
+			fmt.Printf("%2d %-*s", int(s.Number), width, s.Name)
+		}
+	}
+	if len(signals.List())%7 != 0 {
+		fmt.Println()
+	}
+	return true
+}
@@ -0,0 +1,155 @@
+package ls
+
+import (
+	"io/fs"
+	"os"
+	"strings"
+)
+
+// Режимы --color
+const (
+	colorAlways = "always"
+	colorAuto   = "auto"
+	colorNever  = "never"
+)
+
+// defaultColors - встроенные цвета GNU ls; LS_COLORS накладывается поверх
+// них. Битые ссылки (or) и их цели (mi) по умолчанию не выделяются:
+// ссылка остается цвета ln.
+const defaultColors = "rs=0:di=01;34:ln=01;36:pi=33:so=01;35:do=01;35:" +
+	"bd=01;33:cd=01;33:ex=01;32:su=37;41:sg=30;43:st=37;44:ow=34;42:tw=30;42"
+
+// colorDB - цвета из LS_COLORS: по типу файла (di, ln, ex...) и по окончанию имени (*.tar)
+type colorDB struct {
+	types    map[string]string
+	suffixes [][2]string
+}
+
+// colors загружается при первом обращении, только если вывод цветной
+var colors *colorDB
+
+// loadColors собирает цвета: встроенные, а поверх них LS_COLORS. Как
+// в GNU ls, ключи, которых нет в LS_COLORS, сохраняют встроенный цвет.
+func loadColors() *colorDB {
+	db := &colorDB{types: map[string]string{}}
+	db.parse(defaultColors)
+	db.parse(os.Getenv("LS_COLORS"))
+	return db
+}
+
+// parse разбирает строку в формате "ключ=код:ключ=код:*.ext=код"
+func (db *colorDB) parse(spec string) {
+	for _, item := range strings.Split(spec, ":") {
+		key, code, found := strings.Cut(item, "=")
+		if !found || key == "" {
+			continue
+		}
+		if strings.HasPrefix(key, "*") {
+			db.suffixes = append(db.suffixes, [2]string{key[1:], code})
+		} else {
+			db.types[key] = code
+		}
+	}
+}
+
+// fileType возвращает ключ LS_COLORS для файла с данным режимом
+func fileType(mode fs.FileMode) string {
+	switch {
+	case mode&fs.ModeDir != 0:
+		sticky := mode&fs.ModeSticky != 0
+		otherWritable := mode&0o002 != 0
+		switch {
+		case sticky && otherWritable:
+			return "tw"
+		case otherWritable:
+			return "ow"
+		case sticky:
+			return "st"
+		}
+		return "di"
+	case mode&fs.ModeSymlink != 0:
+		return "ln"
+	case mode&fs.ModeNamedPipe != 0:
+		return "pi"
+	case mode&fs.ModeSocket != 0:
+		return "so"
+	case mode&fs.ModeCharDevice != 0:
+		return "cd"
+	case mode&fs.ModeDevice != 0:
+		return "bd"
+	case mode&fs.ModeSetuid != 0:
+		return "su"
+	case mode&fs.ModeSetgid != 0:
+		return "sg"
+	case mode&0o111 != 0:
+		return "ex"
+	}
+	return "fi"
+}
+
+// code возвращает цвет имени файла
+func (db *colorDB) code(name string, mode fs.FileMode, broken bool) string {
+	kind := fileType(mode)
+	if kind == "ln" && broken {
+		if code, ok := db.types["or"]; ok {
+			return code
+		}
+		// ln=target у битой ссылки раскрасить нечем: берется цвет
+		// отсутствующего файла, а если нет и его - без цвета
+		if db.types["ln"] == "target" {
+			return db.types["mi"]
+		}
+	}
+
+	// Окончания имен проверяются только для обычных файлов, как в GNU ls
+	if kind == "fi" {
+		for i := len(db.suffixes) - 1; i >= 0; i-- {
+			suffix := db.suffixes[i]
+			if len(name) >= len(suffix[0]) && strings.EqualFold(name[len(name)-len(suffix[0]):], suffix[0]) {
+				return suffix[1]
+			}
+		}
+	}
+	return db.types[kind]
+}
+
+// paint оборачивает текст в escape-последовательность цвета
+func paint(text, code string) string {
+	if code == "" || code == "0" || code == "00" {
+		return text
+	}
+	return "\033[" + code + "m" + text + "\033[0m"
+}
+
+// paintName раскрашивает имя файла, если вывод цветной. При ln=target
+// ссылка окрашивается цветом файла, на который указывает.
+func paintName(name string, file *FileDetails, config *Config) string {
+	if config.Color == colorNever {
+		return name
+	}
+	if colors == nil {
+		colors = loadColors()
+	}
+
+	mode := file.Mode
+	if mode&fs.ModeSymlink != 0 && !file.LinkBroken && colors.types["ln"] == "target" {
+		mode = file.LinkMode
+	}
+	return paint(name, colors.code(file.Name, mode, file.LinkBroken))
+}
+
+// paintTarget раскрашивает цель символьной ссылки в длинном формате:
+// цветом ее типа или "mi", если ее нет
+func paintTarget(file *FileDetails, config *Config) string {
+	if config.Color == colorNever {
+		return file.LinkTarget
+	}
+	if colors == nil {
+		colors = loadColors()
+	}
+
+	if file.LinkBroken {
+		return paint(file.LinkTarget, colors.types["mi"])
+	}
+	return paint(file.LinkTarget, colors.code(file.LinkTarget, file.LinkMode, false))
+}
@@ -0,0 +1,145 @@
+package ls
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/mir-yks/LinuxCommandAnalog/internal/display"
+)
+
+// Форматы вывода
+const (
+	formatLong    = "long"
+	formatOne     = "single-column"
+	formatColumns = "vertical"
+	formatAcross  = "across"
+	formatCommas  = "commas"
+)
+
+// columnGap - пробелы между колонками; минимальная колонка - один символ и отступ
+const columnGap = 2
+
+// entry - имя файла, готовое к выводу: текст может содержать цветовые
+// escape-последовательности, поэтому ширина на экране хранится отдельно
+type entry struct {
+	text  string
+	width int
+}
+
+// makeEntries готовит имена к выводу: номер inode, цвет и ширину в ячейках
+func makeEntries(files []FileDetails, config *Config) []entry {
+	inodeWidth := 0
+	if config.Inode {
+		for _, file := range files {
+			inodeWidth = max(inodeWidth, len(strconv.FormatUint(file.Inode, 10)))
+		}
+	}
+
+	entries := make([]entry, len(files))
+	for i := range files {
+		file := &files[i]
+		e := entry{text: paintName(file.Name, file, config), width: display.String(file.Name)}
+		if config.Inode {
+			e.text = fmt.Sprintf("%*d %s", inodeWidth, file.Inode, e.text)
+			e.width += inodeWidth + 1
+		}
+		entries[i] = e
+	}
+	return entries
+}
+
+// layoutColumns подбирает наибольшее число колонок, при котором строки
+// помещаются в lineWidth, как это делает GNU ls. У каждой колонки своя
+// ширина, равная самому длинному имени в ней плюс отступ.
+func layoutColumns(entries []entry, lineWidth int, across bool) (cols, rows int, widths []int) {
+	n := len(entries)
+	maxCols := n
+	if lineWidth > 0 {
+		maxCols = min(n, max(1, lineWidth/(1+columnGap)))
+	}
+
+	for cols = maxCols; cols > 1; cols-- {
+		rows = (n + cols - 1) / cols
+		widths = columnWidths(entries, cols, rows, across)
+		total := 0
+		for _, w := range widths {
+			total += w
+		}
+		if lineWidth == 0 || total < lineWidth {
+			return cols, rows, widths
+		}
+	}
+	return 1, n, columnWidths(entries, 1, n, across)
+}
+
+// columnWidths вычисляет ширину каждой колонки; у последней колонки отступа нет
+func columnWidths(entries []entry, cols, rows int, across bool) []int {
+	widths := make([]int, cols)
+	for i, e := range entries {
+		col := i / rows
+		if across {
+			col = i % cols
+		}
+		w := e.width
+		if col != cols-1 {
+			w += columnGap
+		}
+		widths[col] = max(widths[col], w)
+	}
+	return widths
+}
+
+// printColumns выводит имена колонками: сверху вниз (-C) или слева направо (-x)
+func printColumns(entries []entry, lineWidth int, across bool) {
+	if len(entries) == 0 {
+		return
+	}
+
+	cols, rows, widths := layoutColumns(entries, lineWidth, across)
+	var line strings.Builder
+	for row := 0; row < rows; row++ {
+		line.Reset()
+		for col := 0; col < cols; col++ {
+			i, next := col*rows+row, (col+1)*rows+row
+			if across {
+				i, next = row*cols+col, row*cols+col+1
+			}
+			if i >= len(entries) {
+				break
+			}
+			e := entries[i]
+			line.WriteString(e.text)
+			// Пробелы только между именами, не в конце строки
+			if col < cols-1 && next < len(entries) {
+				line.WriteString(strings.Repeat(" ", widths[col]-e.width))
+			}
+		}
+		fmt.Println(line.String())
+	}
+}
+
+// printCommas выводит имена через запятую, перенося строку перед именем,
+// которое не помещается в lineWidth
+func printCommas(entries []entry, lineWidth int) {
+	if len(entries) == 0 {
+		return
+	}
+
+	var out strings.Builder
+	pos := 0
+	for i, e := range entries {
+		if i > 0 {
+			if lineWidth == 0 || pos+e.width+2 < lineWidth {
+				out.WriteString(", ")
+				pos += 2
+			} else {
+				out.WriteString(",\n")
+				pos = 0
+			}
+		}
+		out.WriteString(e.text)
+		pos += e.width
+	}
+	fmt.Println(out.String())
+}
@@ -0,0 +1,150 @@
+package ls
+
+import (
+	"fmt"
+	"io/fs"
+	"os/user"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mir-yks/LinuxCommandAnalog/internal/fsmode"
+	"github.com/mir-yks/LinuxCommandAnalog/internal/human"
+)
+
+// recentPeriod - файлы старше полугода выводятся с годом вместо времени, как в GNU ls
+const recentPeriod = 15778476 * time.Second
+
+var (
+	userNames  = map[uint32]string{}
+	groupNames = map[uint32]string{}
+)
+
+// lookupUser возвращает имя владельца по uid; если имени нет, выводится число
+func lookupUser(uid uint32) string {
+	if name, ok := userNames[uid]; ok {
+		return name
+	}
+	name := strconv.FormatUint(uint64(uid), 10)
+	if u, err := user.LookupId(name); err == nil {
+		name = u.Username
+	}
+	userNames[uid] = name
+	return name
+}
+
+// lookupGroup возвращает имя группы по gid; если имени нет, выводится число
+func lookupGroup(gid uint32) string {
+	if name, ok := groupNames[gid]; ok {
+		return name
+	}
+	name := strconv.FormatUint(uint64(gid), 10)
+	if g, err := user.LookupGroupId(name); err == nil {
+		name = g.Name
+	}
+	groupNames[gid] = name
+	return name
+}
+
+// sizeString возвращает значение столбца размера: байты или -h
+func sizeString(file FileDetails, config *Config) string {
+	if config.Human {
+		return human.Size(uint64(file.Size))
+	}
+	return strconv.FormatInt(file.Size, 10)
+}
+
+// deviceNumbers возвращает старший и младший номера файла устройства
+func deviceNumbers(rdev uint64) (major, minor string) {
+	return strconv.FormatUint((rdev>>8)&0xfff|(rdev>>32)&^0xfff, 10),
+		strconv.FormatUint(rdev&0xff|(rdev>>12)&^0xff, 10)
+}
+
+// printTotal выводит строку "total" для содержимого директории
+func printTotal(files []FileDetails, config *Config) {
+	var blocks int64
+	for _, file := range files {
+		blocks += file.Blocks
+	}
+	// Stat_t.Blocks считается в 512-байтных блоках, ls выводит килобайты
+	total := (blocks + 1) / 2
+	if config.Human {
+		fmt.Printf("total %s\n", human.Size(uint64(total)*1024))
+	} else {
+		fmt.Printf("total %d\n", total)
+	}
+}
+
+// timeString выводит время изменения; для старых и будущих файлов вместо времени выводится год
+func timeString(t time.Time, now time.Time) string {
+	if t.Before(now.Add(-recentPeriod)) || t.After(now) {
+		return t.Format("Jan _2  2006")
+	}
+	return t.Format("Jan _2 15:04")
+}
+
+// displayLong выводит файлы в длинном формате с выровненными столбцами;
+// showTotal добавляет строку "total" с числом занятых килобайтных блоков
+func displayLong(files []FileDetails, config *Config, showTotal bool) {
+	if showTotal {
+		printTotal(files, config)
+	}
+
+	now := time.Now()
+	rows := make([][6]string, len(files))
+	var widths [6]int
+	var majorWidth, minorWidth int
+	for i, file := range files {
+		size := ""
+		if file.Mode&fs.ModeDevice != 0 {
+			major, minor := deviceNumbers(file.Rdev)
+			majorWidth = max(majorWidth, len(major))
+			minorWidth = max(minorWidth, len(minor))
+		} else {
+			size = sizeString(file, config)
+		}
+		rows[i] = [6]string{
+			strconv.FormatUint(file.Inode, 10),
+			strconv.FormatUint(file.Nlink, 10),
+			lookupUser(file.Uid),
+			lookupGroup(file.Gid),
+			size,
+			timeString(file.ModTime, now),
+		}
+		for col, value := range rows[i] {
+			widths[col] = max(widths[col], len(value))
+		}
+	}
+
+	// У файлов устройств вместо размера два столбца, старший и младший
+	// номер, каждый со своей шириной, как в GNU ls: "  5,   1"
+	if majorWidth > 0 {
+		widths[4] = max(widths[4], majorWidth+2+minorWidth)
+		for i, file := range files {
+			if file.Mode&fs.ModeDevice != 0 {
+				major, minor := deviceNumbers(file.Rdev)
+				rows[i][4] = fmt.Sprintf("%*s, %*s", widths[4]-2-minorWidth, major, minorWidth, minor)
+			}
+		}
+	}
+
+	for i, file := range files {
+		row := rows[i]
+		var line strings.Builder
+		if config.Inode {
+			fmt.Fprintf(&line, "%*s ", widths[0], row[0])
+		}
+		fmt.Fprintf(&line, "%s %*s %-*s %-*s %*s %s %s",
+			fsmode.String(file.Mode),
+			widths[1], row[1],
+			widths[2], row[2],
+			widths[3], row[3],
+			widths[4], row[4],
+			row[5],
+			paintName(file.Name, &file, config))
+		if file.LinkTarget != "" {
+			line.WriteString(" -> " + paintTarget(&file, config))
+		}
+		fmt.Println(line.String())
+	}
+}
@@ -0,0 +1,435 @@
+package ls
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/mir-yks/LinuxCommandAnalog/internal/applet"
+	"github.com/mir-yks/LinuxCommandAnalog/internal/getopt"
+	"github.com/mir-yks/LinuxCommandAnalog/internal/input"
+	"github.com/mir-yks/LinuxCommandAnalog/internal/term"
+)
+
+type Config struct {
+	Help      bool
+	Version   bool
+	All       bool
+	Format    string // formatLong, formatColumns и т.д.; по умолчанию зависит от вывода
+	Reverse   bool
+	Recursive bool
+	Human     bool // размеры в длинном формате в виде 1.5K, 20M
+	Inode     bool // выводить номер inode
+	AlmostAll bool // скрытые файлы без . и ..
+	Directory bool // выводить сами директории, а не их содержимое
+	GroupDirs bool // директории перед файлами
+	Sort      string
+	Ignore    []string // шаблоны имен, которые не выводятся
+	Width     int      // ширина строки для колонок и -m; 0 - без ограничения
+	Color     string   // auto, always или never
+	Paths     []string
+}
+
+const ver = "1.0.0"
+
+func init() {
+	applet.Register("ls", Main)
+}
+
+// Main запускает утилиту ls
+func Main() {
+	defer func() {
+		if r := recover(); r != nil {
+			fmt.Fprintf(os.Stderr, "Ошибка: %v\n", r)
+			os.Exit(1)
+		}
+	}()
+
+	config := parseArgs()
+
+	if config.Help {
+		printHelp()
+		return
+	}
+
+	if config.Version {
+		printVersion()
+		return
+	}
+
+	if len(config.Paths) == 0 {
+		config.Paths = append(config.Paths, ".")
+	}
+
+	executeLs(config)
+}
+
+// parseArgs разбирает аргументы командной строки
+func parseArgs() *Config {
+	config := &Config{Sort: sortName, Width: -1, Color: colorNever}
+
+	// Ключи сортировки взаимоисключающие: действует последний указанный
+	sortKey := func(key string) func(string) error {
+		return func(string) error {
+			config.Sort = key
+			return nil
+		}
+	}
+
+	// Ключи формата тоже взаимоисключающие
+	format := func(value string) func(string) error {
+		return func(string) error {
+			config.Format = value
+			return nil
+		}
+	}
+
+	opts := getopt.New("ls")
+	opts.Bool(&config.Help, 0, "help")
+	opts.Bool(&config.Version, 0, "version")
+	opts.Bool(&config.All, 'a', "all")
+	opts.Bool(&config.AlmostAll, 'A', "almost-all")
+	opts.Func('l', "", getopt.NoArgument, format(formatLong))
+	opts.Func('1', "", getopt.NoArgument, format(formatOne))
+	opts.Func('C', "", getopt.NoArgument, format(formatColumns))
+	opts.Func('x', "", getopt.NoArgument, format(formatAcross))
+	opts.Func('m', "", getopt.NoArgument, format(formatCommas))
+	opts.Func(0, "format", getopt.RequiredArgument, func(value string) error {
+		switch value {
+		case "long", "verbose":
+			config.Format = formatLong
+		case "single-column":
+			config.Format = formatOne
+		case "vertical":
+			config.Format = formatColumns
+		case "across", "horizontal":
+			config.Format = formatAcross
+		case "commas":
+			config.Format = formatCommas
+		default:
+			return fmt.Errorf("неверный аргумент '%s' для '--format'", value)
+		}
+		return nil
+	})
+	opts.Func('w', "width", getopt.RequiredArgument, func(value string) error {
+		width, err := strconv.Atoi(value)
+		if err != nil || width < 0 {
+			return fmt.Errorf("неверная ширина строки: '%s'", value)
+		}
+		config.Width = width
+		return nil
+	})
+	opts.Func(0, "color", getopt.OptionalArgument, func(value string) error {
+		switch value {
+		case "", "always", "yes", "force":
+			config.Color = colorAlways
+		case "auto", "tty", "if-tty":
+			config.Color = colorAuto
+		case "never", "no", "none":
+			config.Color = colorNever
+		default:
+			return fmt.Errorf("неверный аргумент '%s' для '--color'", value)
+		}
+		return nil
+	})
+	opts.Bool(&config.Reverse, 'r', "reverse")
+	opts.Bool(&config.Recursive, 'R', "recursive")
+	opts.Bool(&config.Human, 'h', "human-readable")
+	opts.Bool(&config.Inode, 'i', "inode")
+	opts.Bool(&config.Directory, 'd', "directory")
+	opts.Bool(&config.GroupDirs, 0, "group-directories-first")
+	opts.Func('t', "", getopt.NoArgument, sortKey(sortTime))
+	opts.Func('S', "", getopt.NoArgument, sortKey(sortSize))
+	opts.Func('X', "", getopt.NoArgument, sortKey(sortExtension))
+	opts.Func('v', "", getopt.NoArgument, sortKey(sortVersion))
+	opts.Func('U', "", getopt.NoArgument, sortKey(sortNone))
+	opts.Func(0, "sort", getopt.RequiredArgument, func(value string) error {
+		switch value {
+		case "name", "time", "size", "extension", "version", "none":
+			config.Sort = value
+			return nil
+		}
+		return fmt.Errorf("неверный аргумент '%s' для '--sort'", value)
+	})
+	opts.Strings(&config.Ignore, 'I', "ignore")
+	opts.Func('B', "ignore-backups", getopt.NoArgument, func(string) error {
+		config.Ignore = append(config.Ignore, "*~", ".*~")
+		return nil
+	})
+	config.Paths = opts.Parse(os.Args[1:])
+
+	// Как GNU ls: на терминал - колонками, в канал или файл - по одному имени в строке
+	stdoutTerminal := term.IsTerminal(os.Stdout.Fd())
+	if config.Format == "" {
+		if stdoutTerminal {
+			config.Format = formatColumns
+		} else {
+			config.Format = formatOne
+		}
+	}
+	if config.Width < 0 {
+		config.Width = term.StdoutWidth()
+	}
+	if config.Color == colorAuto && !stdoutTerminal {
+		config.Color = colorNever
+	}
+
+	return config
+}
+
+// printHelp выводит справку по использованию программы
+func printHelp() {
+	fmt.Println("ls - перечисление содержимого директорий")
+	fmt.Println()
+	fmt.Println("Использование: ls [ОПЦИЯ]... [ПУТЬ]...")
+	fmt.Println()
+	fmt.Println("Опции:")
+	fmt.Println("  -a     включить скрытые файлы, а также . и ..")
+	fmt.Println("  -A     включить скрытые файлы, кроме . и ..")
+	fmt.Println("  -d     выводить сами директории, а не их содержимое")
+	fmt.Println("  -B, --ignore-backups      не выводить файлы, оканчивающиеся на ~")
+	fmt.Println("  -I, --ignore=ШАБЛОН       не выводить файлы, подходящие под ШАБЛОН")
+	fmt.Println("  -l     длинный формат: права, ссылки, владелец, группа, размер, время")
+	fmt.Println("  -1     по одному файлу в строке (по умолчанию, если вывод не на терминал)")
+	fmt.Println("  -C     колонками сверху вниз (по умолчанию на терминале)")
+	fmt.Println("  -x     колонками слева направо")
+	fmt.Println("  -m     через запятую")
+	fmt.Println("  -w, --width=ЧИСЛО         ширина строки вместо ширины терминала; 0 - без ограничения")
+	fmt.Println("  --color[=КОГДА]           раскрашивать имена по LS_COLORS: always (по умолчанию), auto, never")
+	fmt.Println("  -h     вместе с -l: размеры в виде 1K, 234M, 2G")
+	fmt.Println("  -i     выводить номер inode каждого файла")
+	fmt.Println("  -r     обратить порядок")
+	fmt.Println("  -R     рекурсивный вывод")
+	fmt.Println()
+	fmt.Println("Сортировка (по умолчанию - по имени):")
+	fmt.Println("  -t     по времени изменения, новые первыми")
+	fmt.Println("  -S     по размеру, большие первыми")
+	fmt.Println("  -X     по расширению")
+	fmt.Println("  -v     по номерам версий внутри имен (file2 раньше file10)")
+	fmt.Println("  -U     не сортировать, порядок директории")
+	fmt.Println("  --sort=СЛОВО              name, time, size, extension, version, none")
+	fmt.Println("  --group-directories-first директории перед файлами")
+	fmt.Println()
+	fmt.Println("  --help    показать эту справку")
+	fmt.Println("  --version показать информацию о версии")
+	fmt.Println()
+	fmt.Println("Примеры:")
+	fmt.Println("  ls                     # Текущая директория")
+	fmt.Println("  ls -l /tmp            # Длинный формат")
+	fmt.Println("  ls -lhi /var/log      # Размеры в K/M/G и номера inode")
+	fmt.Println("  ls -a -r dir          # Скрытые файлы, обратный порядок")
+	fmt.Println("  ls -ltr build         # Последние измененные файлы внизу")
+	fmt.Println("  ls -ld /etc /tmp      # Сведения о самих директориях")
+	fmt.Println("  ls --color=auto -x    # Цветные имена, колонки слева направо")
+}
+
+// printVersion выводит информацию о версии программы
+func printVersion() {
+	fmt.Println("ls версия", ver)
+	fmt.Println("Разработано в рамках учебного проекта")
+	fmt.Println("Язык программирования: Golang")
+}
+
+// executeLs выполняет перечисление файлов для всех указанных путей.
+// Как и GNU ls, сначала выводит операнды-файлы, затем содержимое директорий
+// с заголовками, если операндов несколько.
+func executeLs(config *Config) {
+	failed := false
+	var files []FileDetails
+	var dirs []FileDetails
+
+	for _, path := range config.Paths {
+		info, err := os.Lstat(path)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "ls: невозможно получить доступ к '%s': %v\n", path, input.Describe(err))
+			failed = true
+			continue
+		}
+
+		// Ссылку на директорию в командной строке ls раскрывает,
+		// если не нужно показать саму ссылку. Ссылка на файл остается
+		// ссылкой и окрашивается как ссылка.
+		if info.Mode()&fs.ModeSymlink != 0 && config.Format != formatLong && !config.Directory {
+			if target, err := os.Stat(path); err == nil && target.IsDir() {
+				info = target
+			}
+		}
+
+		details := newFileDetails(path, info)
+		details.Name = path
+		if info.IsDir() && !config.Directory {
+			dirs = append(dirs, details)
+		} else {
+			files = append(files, details)
+		}
+	}
+
+	if len(files) > 0 {
+		sortFiles(files, config)
+		displayFiles(files, config, false)
+	}
+
+	sortFiles(dirs, config)
+	headers := len(config.Paths) > 1 || config.Recursive
+	for i, dir := range dirs {
+		if len(files) > 0 || i > 0 {
+			fmt.Println()
+		}
+		if err := listDirectory(dir.Name, config, headers); err != nil {
+			fmt.Fprintf(os.Stderr, "ls: %v\n", err)
+			failed = true
+		}
+	}
+
+	if failed {
+		os.Exit(2)
+	}
+}
+
+// FileDetails содержит всю информацию о файле для отображения
+type FileDetails struct {
+	Name       string
+	Size       int64
+	ModTime    time.Time
+	Mode       fs.FileMode
+	IsDir      bool
+	Inode      uint64
+	Nlink      uint64
+	Uid        uint32
+	Gid        uint32
+	Blocks     int64       // занято 512-байтных блоков
+	Rdev       uint64      // номер устройства для файлов устройств
+	LinkTarget string      // куда указывает символьная ссылка
+	LinkMode   fs.FileMode // тип и права файла, на который указывает ссылка
+	LinkBroken bool        // ссылка указывает на несуществующий файл
+}
+
+// newFileDetails собирает сведения о файле из os.FileInfo и syscall.Stat_t
+func newFileDetails(path string, info fs.FileInfo) FileDetails {
+	details := FileDetails{
+		Name:    info.Name(),
+		Size:    info.Size(),
+		ModTime: info.ModTime(),
+		Mode:    info.Mode(),
+		IsDir:   info.IsDir(),
+	}
+
+	if st, ok := info.Sys().(*syscall.Stat_t); ok {
+		details.Inode = uint64(st.Ino)
+		details.Nlink = uint64(st.Nlink)
+		details.Uid = st.Uid
+		details.Gid = st.Gid
+		details.Blocks = int64(st.Blocks)
+		details.Rdev = uint64(st.Rdev)
+	}
+
+	if info.Mode()&fs.ModeSymlink != 0 {
+		details.LinkTarget, _ = os.Readlink(path)
+		if target, err := os.Stat(path); err == nil {
+			details.LinkMode = target.Mode()
+		} else {
+			details.LinkBroken = true
+		}
+	}
+
+	return details
+}
+
+// listDirectory читает содержимое директории и подготавливает данные для вывода
+func listDirectory(dirPath string, config *Config, header bool) error {
+	dir, err := os.Open(dirPath)
+	if err != nil {
+		return fmt.Errorf("не удалось открыть директорию '%s': %v", dirPath, input.Describe(err))
+	}
+	// ReadDir(-1) возвращает записи в порядке директории, который нужен для -U
+	entries, err := dir.ReadDir(-1)
+	dir.Close()
+	if err != nil {
+		return fmt.Errorf("не удалось прочитать '%s': %v", dirPath, input.Describe(err))
+	}
+
+	var files []FileDetails
+	if config.All {
+		for _, name := range []string{".", ".."} {
+			if info, err := os.Lstat(filepath.Join(dirPath, name)); err == nil {
+				details := newFileDetails(dirPath, info)
+				details.Name = name
+				files = append(files, details)
+			}
+		}
+	}
+
+	for _, entry := range entries {
+		name := entry.Name()
+		if !showEntry(name, config) {
+			continue
+		}
+
+		info, err := entry.Info()
+		if err != nil {
+			continue
+		}
+
+		files = append(files, newFileDetails(filepath.Join(dirPath, name), info))
+	}
+
+	sortFiles(files, config)
+
+	if header {
+		fmt.Printf("%s:\n", dirPath)
+	}
+
+	displayFiles(files, config, true)
+
+	if config.Recursive {
+		for _, file := range files {
+			if file.IsDir && file.Name != "." && file.Name != ".." {
+				fmt.Println()
+				// Путь склеивается без очистки, чтобы заголовки были как в GNU ls: ./dir:
+				subdir := strings.TrimSuffix(dirPath, "/") + "/" + file.Name
+				if err := listDirectory(subdir, config, true); err != nil {
+					fmt.Fprintf(os.Stderr, "ls: %v\n", err)
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// showEntry решает, выводить ли запись директории с учетом -a, -A и --ignore
+func showEntry(name string, config *Config) bool {
+	if strings.HasPrefix(name, ".") && !config.All && !config.AlmostAll {
+		return false
+	}
+	for _, pattern := range config.Ignore {
+		if matched, _ := filepath.Match(pattern, name); matched {
+			return false
+		}
+	}
+	return true
+}
+
+// displayFiles выводит список в выбранном формате;
+// строка total выводится только для содержимого директорий
+func displayFiles(files []FileDetails, config *Config, total bool) {
+	switch config.Format {
+	case formatLong:
+		displayLong(files, config, total)
+	case formatColumns:
+		printColumns(makeEntries(files, config), config.Width, false)
+	case formatAcross:
+		printColumns(makeEntries(files, config), config.Width, true)
+	case formatCommas:
+		printCommas(makeEntries(files, config), config.Width)
+	default:
+		for _, e := range makeEntries(files, config) {
+			fmt.Println(e.text)
+		}
+	}
+}
@@ -0,0 +1,128 @@
+package ls
+
+import (
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Ключи сортировки (значения --sort)
+const (
+	sortName      = "name"
+	sortTime      = "time"
+	sortSize      = "size"
+	sortExtension = "extension"
+	sortVersion   = "version"
+	sortNone      = "none"
+)
+
+// sortFiles упорядочивает файлы по выбранному ключу. При равенстве ключей
+// файлы сортируются по имени; -r обращает порядок, но директории при
+// --group-directories-first остаются первыми. При -U порядок не меняется.
+func sortFiles(files []FileDetails, config *Config) {
+	if config.Sort == sortNone {
+		if config.GroupDirs {
+			sort.SliceStable(files, func(i, j int) bool {
+				return files[i].IsDir && !files[j].IsDir
+			})
+		}
+		return
+	}
+
+	compare := compareFunc(config.Sort)
+	sort.SliceStable(files, func(i, j int) bool {
+		a, b := &files[i], &files[j]
+		if config.GroupDirs && a.IsDir != b.IsDir {
+			return a.IsDir
+		}
+		c := compare(a, b)
+		if c == 0 {
+			c = strings.Compare(a.Name, b.Name)
+		}
+		if config.Reverse {
+			return c > 0
+		}
+		return c < 0
+	})
+}
+
+// compareFunc возвращает сравнение для ключа сортировки: отрицательное
+// значение значит, что a выводится раньше b
+func compareFunc(key string) func(a, b *FileDetails) int {
+	switch key {
+	case sortTime:
+		// Новые файлы первыми
+		return func(a, b *FileDetails) int {
+			return b.ModTime.Compare(a.ModTime)
+		}
+	case sortSize:
+		// Большие файлы первыми
+		return func(a, b *FileDetails) int {
+			switch {
+			case a.Size > b.Size:
+				return -1
+			case a.Size < b.Size:
+				return 1
+			}
+			return 0
+		}
+	case sortExtension:
+		return func(a, b *FileDetails) int {
+			return strings.Compare(extension(a.Name), extension(b.Name))
+		}
+	case sortVersion:
+		return func(a, b *FileDetails) int {
+			return versionCompare(a.Name, b.Name)
+		}
+	}
+	return func(a, b *FileDetails) int {
+		return 0
+	}
+}
+
+// extension возвращает расширение имени; у скрытых файлов вроде .bashrc его нет
+func extension(name string) string {
+	name = filepath.Base(name)
+	if dot := strings.LastIndexByte(name, '.'); dot > 0 {
+		return name[dot:]
+	}
+	return ""
+}
+
+// versionCompare сравнивает имена с учетом чисел внутри них:
+// file2 < file10, linux-5.9 < linux-5.10. Числа сравниваются по значению,
+// остальные части - посимвольно.
+func versionCompare(a, b string) int {
+	for a != "" && b != "" {
+		if isDigit(a[0]) && isDigit(b[0]) {
+			na, nb := digitPrefix(a), digitPrefix(b)
+			ta, tb := strings.TrimLeft(a[:na], "0"), strings.TrimLeft(b[:nb], "0")
+			if len(ta) != len(tb) {
+				return len(ta) - len(tb)
+			}
+			if c := strings.Compare(ta, tb); c != 0 {
+				return c
+			}
+			a, b = a[na:], b[nb:]
+			continue
+		}
+		if a[0] != b[0] {
+			return int(a[0]) - int(b[0])
+		}
+		a, b = a[1:], b[1:]
+	}
+	return len(a) - len(b)
+}
+
+// digitPrefix возвращает длину последовательности цифр в начале строки
+func digitPrefix(s string) int {
+	n := 0
+	for n < len(s) && isDigit(s[n]) {
+		n++
+	}
+	return n
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
@@ -1,8 +1,11 @@
-package main
+package mkdir
 
 import (
 	"fmt"
 	"os"
+
+	"github.com/mir-yks/LinuxCommandAnalog/internal/applet"
+	"github.com/mir-yks/LinuxCommandAnalog/internal/getopt"
 )
 
 type Config struct {
@@ -15,7 +18,12 @@ type Config struct {
 
 const ver = "1.0.0"
 
-func main() {
+func init() {
+	applet.Register("mkdir", Main)
+}
+
+// Main запускает утилиту mkdir
+func Main() {
 	defer func() {
 		if r := recover(); r != nil {
 			fmt.Fprintf(os.Stderr, "Ошибка: %v\n", r)
@@ -35,62 +43,26 @@ func main() {
 		return
 	}
 
-	if len(config.Directories) == 0 {
-		fmt.Fprintln(os.Stderr, "mkdir: пропущен путь")
-		fmt.Fprintln(os.Stderr, "По команде «mkdir -h» можно получить дополнительную информацию.")
-		os.Exit(1)
-	}
-
 	executeMkdir(config)
 }
 
-// parseArgs разбирает аргументы командной строки вручную
+// parseArgs разбирает аргументы командной строки
 func parseArgs() *Config {
 	config := &Config{}
-	i := 1
-
-	for i < len(os.Args) {
-		arg := os.Args[i]
-
-		switch arg {
-		case "-h":
-			config.Help = true
-			i++
-			continue
-		case "-v":
-			config.Verbose = true
-			i++
-			continue
-		case "-p":
-			config.Parents = true
-			i++
-			continue
-		case "--version":
-			config.Version = true
-			i++
-			continue
-		default:
-			if len(arg) > 1 && arg[0] == '-' {
-				for _, ch := range arg[1:] {
-					switch ch {
-					case 'h':
-						config.Help = true
-					case 'v':
-						config.Verbose = true
-					case 'p':
-						config.Parents = true
-					default:
-						panic(fmt.Sprintf("mkdir: неверный ключ — '%s'", arg))
-					}
-				}
-				i++
-				continue
-			}
-			config.Directories = append(config.Directories, arg)
-			i++
-		}
-	}
 
+	opts := getopt.New("mkdir")
+	opts.Bool(&config.Help, 'h', "help")
+	opts.Bool(&config.Verbose, 'v', "verbose")
+	opts.Bool(&config.Parents, 'p', "parents")
+	opts.Bool(&config.Version, 0, "version")
+	config.Directories = opts.Parse(os.Args[1:])
+
+	if config.Help || config.Version {
+		return config
+	}
+	if len(config.Directories) == 0 {
+		opts.Failf("пропущен операнд")
+	}
 	return config
 }
 
@@ -0,0 +1,422 @@
+package nl
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/mir-yks/LinuxCommandAnalog/internal/applet"
+	"github.com/mir-yks/LinuxCommandAnalog/internal/getopt"
+	"github.com/mir-yks/LinuxCommandAnalog/internal/input"
+)
+
+// Разделы логической страницы
+const (
+	header = iota
+	body
+	footer
+)
+
+// style - способ нумерации строк раздела: a - все, t - непустые,
+// n - никакие, p - подходящие под регулярное выражение
+type style struct {
+	kind  byte
+	regex *regexp.Regexp
+}
+
+type Config struct {
+	Help       bool
+	Version    bool
+	Styles     [3]style // стили заголовка, тела и подвала
+	Delimiter  string   // -d: разделитель разделов; пустой - разделов нет
+	Increment  int64    // -i: шаг номера
+	JoinBlank  int64    // -l: группа из N пустых строк получает один номер
+	NumberFmt  string   // -n: ln, rn или rz
+	NoRenumber bool     // -p: не сбрасывать номер в начале раздела
+	Separator  string   // -s: строка между номером и текстом
+	Start      int64    // -v: первый номер
+	Width      int      // -w: ширина поля номера
+	Filenames  []string
+}
+
+const ver = "1.0.0"
+
+// printHelp выводит справку по использованию утилиты nl
+func printHelp() {
+	fmt.Println("nl - нумерация строк")
+	fmt.Println()
+	fmt.Println("Использование: nl [ОПЦИЯ]... [ФАЙЛ]...")
+	fmt.Println()
+	fmt.Println("Файлы нумеруются как один поток. Если ФАЙЛ не задан или задан как -,")
+	fmt.Println("читается стандартный ввод.")
+	fmt.Println()
+	fmt.Println("Опции:")
+	fmt.Println("  -b, --body-numbering=СТИЛЬ      нумерация тела (по умолчанию t)")
+	fmt.Println("  -h, --header-numbering=СТИЛЬ    нумерация заголовка (по умолчанию n)")
+	fmt.Println("  -f, --footer-numbering=СТИЛЬ    нумерация подвала (по умолчанию n)")
+	fmt.Println("  -d, --section-delimiter=СС      разделитель разделов (по умолчанию \\:)")
+	fmt.Println("  -i, --line-increment=N          шаг номера (по умолчанию 1)")
+	fmt.Println("  -l, --join-blank-lines=N        N пустых строк подряд считаются одной")
+	fmt.Println("  -n, --number-format=ФОРМАТ      формат номера: ln, rn или rz")
+	fmt.Println("  -p, --no-renumber               не сбрасывать номер в начале раздела")
+	fmt.Println("  -s, --number-separator=СТРОКА   строка после номера (по умолчанию TAB)")
+	fmt.Println("  -v, --starting-line-number=N    первый номер (по умолчанию 1)")
+	fmt.Println("  -w, --number-width=N            ширина поля номера (по умолчанию 6)")
+	fmt.Println("  --help                          показать эту справку")
+	fmt.Println("  --version                       показать информацию о версии")
+	fmt.Println()
+	fmt.Println("СТИЛЬ:")
+	fmt.Println("  a        нумеровать все строки")
+	fmt.Println("  t        нумеровать только непустые строки")
+	fmt.Println("  n        не нумеровать строки")
+	fmt.Println("  pРЕГВЫР  нумеровать строки, подходящие под базовое регулярное выражение")
+	fmt.Println()
+	fmt.Println("ФОРМАТ:")
+	fmt.Println("  ln       по левому краю, без ведущих нулей")
+	fmt.Println("  rn       по правому краю, без ведущих нулей")
+	fmt.Println("  rz       по правому краю, с ведущими нулями")
+	fmt.Println()
+	fmt.Println("Входные данные делятся на логические страницы из заголовка, тела и")
+	fmt.Println("подвала. Строка из одного разделителя СС три раза подряд начинает")
+	fmt.Println("заголовок, два раза - тело, один раз - подвал; такие строки выводятся")
+	fmt.Println("пустыми. В начале каждого раздела номер сбрасывается, если не задан -p.")
+	fmt.Println()
+	fmt.Println("Примеры:")
+	fmt.Println("  nl file.txt                 # Нумеровать непустые строки")
+	fmt.Println("  nl -b a -w 4 file.txt       # Все строки, ширина номера 4")
+	fmt.Println("  nl -n rz -s ': ' main.go    # Номера с нулями и двоеточием")
+	fmt.Println("  nl -b p'^func' main.go      # Только строки с объявлением функций")
+	fmt.Println("  nl -v 10 -i 10 a.txt b.txt  # 10, 20, 30... через два файла")
+}
+
+// printVersion выводит информацию о версии
+func printVersion() {
+	fmt.Println("nl версия", ver)
+	fmt.Println("Разработано в рамках учебного проекта")
+	fmt.Println("Язык программирования: Golang")
+}
+
+// parseArgs разбирает аргументы командной строки
+func parseArgs() *Config {
+	config := &Config{
+		Styles:    [3]style{{kind: 'n'}, {kind: 't'}, {kind: 'n'}},
+		Delimiter: `\:`,
+		Increment: 1,
+		JoinBlank: 1,
+		NumberFmt: "rn",
+		Separator: "\t",
+		Start:     1,
+		Width:     6,
+	}
+
+	opts := getopt.New("nl")
+	opts.Bool(&config.Help, 0, "help")
+	opts.Bool(&config.Version, 0, "version")
+	styleOption := func(section int) func(string) error {
+		return func(value string) error {
+			s, err := parseStyle(value)
+			if err != nil {
+				return err
+			}
+			config.Styles[section] = s
+			return nil
+		}
+	}
+	opts.Func('b', "body-numbering", getopt.RequiredArgument, styleOption(body))
+	opts.Func('h', "header-numbering", getopt.RequiredArgument, styleOption(header))
+	opts.Func('f', "footer-numbering", getopt.RequiredArgument, styleOption(footer))
+	opts.Func('d', "section-delimiter", getopt.RequiredArgument, func(value string) error {
+		// Одиночный символ дополняется двоеточием, как в POSIX
+		if len(value) == 1 {
+			value += ":"
+		}
+		config.Delimiter = value
+		return nil
+	})
+	opts.Func('i', "line-increment", getopt.RequiredArgument, func(value string) error {
+		n, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return fmt.Errorf("неверный шаг номера '%s'", value)
+		}
+		config.Increment = n
+		return nil
+	})
+	opts.Func('l', "join-blank-lines", getopt.RequiredArgument, func(value string) error {
+		n, err := strconv.ParseInt(value, 10, 64)
+		if err != nil || n < 1 {
+			return fmt.Errorf("неверное число пустых строк '%s'", value)
+		}
+		config.JoinBlank = n
+		return nil
+	})
+	opts.Func('n', "number-format", getopt.RequiredArgument, func(value string) error {
+		if value != "ln" && value != "rn" && value != "rz" {
+			return fmt.Errorf("неверный формат '%s'. Используйте 'ln', 'rn' или 'rz'", value)
+		}
+		config.NumberFmt = value
+		return nil
+	})
+	opts.Bool(&config.NoRenumber, 'p', "no-renumber")
+	opts.String(&config.Separator, 's', "number-separator")
+	opts.Func('v', "starting-line-number", getopt.RequiredArgument, func(value string) error {
+		n, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return fmt.Errorf("неверный первый номер '%s'", value)
+		}
+		config.Start = n
+		return nil
+	})
+	opts.Func('w', "number-width", getopt.RequiredArgument, func(value string) error {
+		width, err := strconv.Atoi(value)
+		if err != nil || width < 1 {
+			return fmt.Errorf("неверная ширина номера '%s'", value)
+		}
+		config.Width = width
+		return nil
+	})
+	config.Filenames = input.Operands(opts.Parse(os.Args[1:]))
+
+	return config
+}
+
+// parseStyle разбирает стиль нумерации: a, t, n или pРЕГВЫР
+func parseStyle(value string) (style, error) {
+	switch {
+	case value == "a" || value == "t" || value == "n":
+		return style{kind: value[0]}, nil
+	case strings.HasPrefix(value, "p"):
+		regex, err := regexp.Compile(convertBRE(value[1:]))
+		if err != nil {
+			return style{}, fmt.Errorf("неверное регулярное выражение '%s'", value[1:])
+		}
+		return style{kind: 'p', regex: regex}, nil
+	}
+	return style{}, fmt.Errorf("неверный стиль '%s'. Используйте 'a', 't', 'n' или 'pРЕГВЫР'", value)
+}
+
+// convertBRE переводит базовое регулярное выражение POSIX в синтаксис Go:
+// в BRE \( \) \{ \} \| \+ \? - операторы, а те же символы без обратной
+// косой черты - обычные
+func convertBRE(bre string) string {
+	var re strings.Builder
+	for i := 0; i < len(bre); i++ {
+		c := bre[i]
+		switch {
+		case c == '\\' && i+1 < len(bre):
+			i++
+			next := bre[i]
+			if strings.IndexByte("(){}|+?", next) >= 0 {
+				re.WriteByte(next)
+			} else {
+				re.WriteByte('\\')
+				re.WriteByte(next)
+			}
+		case c == '[':
+			// Скобочное выражение переносится как есть до закрывающей ]
+			end := bracketEnd(bre, i)
+			re.WriteString(bre[i:end])
+			i = end - 1
+		case strings.IndexByte("(){}|+?", c) >= 0:
+			re.WriteByte('\\')
+			re.WriteByte(c)
+		case c == '*' && (i == 0 || bre[i-1] == '^' && i == 1):
+			// * в начале выражения - обычный символ
+			re.WriteString(`\*`)
+		default:
+			re.WriteByte(c)
+		}
+	}
+	return re.String()
+}
+
+// bracketEnd возвращает позицию за скобочным выражением, начатым в start;
+// ] сразу после [ или [^ - обычный символ
+func bracketEnd(s string, start int) int {
+	i := start + 1
+	if i < len(s) && s[i] == '^' {
+		i++
+	}
+	if i < len(s) && s[i] == ']' {
+		i++
+	}
+	for ; i < len(s); i++ {
+		if s[i] == '[' && i+1 < len(s) && strings.IndexByte(":.=", s[i+1]) >= 0 {
+			// Класс [:alpha:] и подобные
+			if end := strings.Index(s[i+2:], string(s[i+1])+"]"); end >= 0 {
+				i += end + 3
+				continue
+			}
+		}
+		if s[i] == ']' {
+			return i + 1
+		}
+	}
+	return len(s)
+}
+
+// numberer хранит состояние нумерации, общее для всех файлов
+type numberer struct {
+	config  *Config
+	out     *bufio.Writer
+	section int
+	number  int64
+	blanks  int64  // пустые строки подряд, еще не получившие номер
+	padding string // замена номера и разделителя в ненумерованных строках
+}
+
+func newNumberer(config *Config, out *bufio.Writer) *numberer {
+	return &numberer{
+		config:  config,
+		out:     out,
+		section: body,
+		number:  config.Start,
+		padding: strings.Repeat(" ", config.Width+len(config.Separator)),
+	}
+}
+
+// sectionOf возвращает раздел, который начинает строка-разделитель, или -1
+func (n *numberer) sectionOf(line string) int {
+	delim := n.config.Delimiter
+	if delim == "" || len(line)%len(delim) != 0 {
+		return -1
+	}
+	switch line {
+	case strings.Repeat(delim, 3):
+		return header
+	case strings.Repeat(delim, 2):
+		return body
+	case delim:
+		return footer
+	}
+	return -1
+}
+
+// numberLine решает, нужен ли строке номер в текущем разделе
+func (n *numberer) numberLine(line string) bool {
+	s := n.config.Styles[n.section]
+	switch s.kind {
+	case 'a':
+		if line != "" || n.config.JoinBlank <= 1 {
+			n.blanks = 0
+			return true
+		}
+		n.blanks++
+		if n.blanks == n.config.JoinBlank {
+			n.blanks = 0
+			return true
+		}
+		return false
+	case 't':
+		return line != ""
+	case 'p':
+		return s.regex.MatchString(line)
+	}
+	return false
+}
+
+// format форматирует номер строки
+func (n *numberer) format() string {
+	switch n.config.NumberFmt {
+	case "ln":
+		return fmt.Sprintf("%-*d", n.config.Width, n.number)
+	case "rz":
+		return fmt.Sprintf("%0*d", n.config.Width, n.number)
+	}
+	return fmt.Sprintf("%*d", n.config.Width, n.number)
+}
+
+// write выводит одну строку без перевода строки
+func (n *numberer) write(line string) {
+	if section := n.sectionOf(line); section >= 0 {
+		n.section = section
+		if !n.config.NoRenumber {
+			n.number = n.config.Start
+		}
+		n.out.WriteString("\n")
+		return
+	}
+
+	if n.numberLine(line) {
+		n.out.WriteString(n.format())
+		n.out.WriteString(n.config.Separator)
+		n.number += n.config.Increment
+	} else {
+		n.out.WriteString(n.padding)
+	}
+	n.out.WriteString(line)
+	n.out.WriteString("\n")
+}
+
+// numberFile нумерует строки одного файла; последняя строка без перевода
+// строки выводится с ним
+func (n *numberer) numberFile(r io.Reader) error {
+	reader := bufio.NewReader(r)
+	for {
+		line, err := reader.ReadString('\n')
+		if line != "" {
+			n.write(strings.TrimSuffix(line, "\n"))
+		}
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+func init() {
+	applet.Register("nl", Main)
+}
+
+// Main запускает утилиту nl
+func Main() {
+	defer func() {
+		if r := recover(); r != nil {
+			fmt.Fprintf(os.Stderr, "nl: %v\n", r)
+			os.Exit(1)
+		}
+	}()
+
+	config := parseArgs()
+
+	if config.Help {
+		printHelp()
+		return
+	}
+
+	if config.Version {
+		printVersion()
+		return
+	}
+
+	out := bufio.NewWriter(os.Stdout)
+	defer out.Flush()
+	n := newNumberer(config, out)
+
+	ok := true
+	for _, path := range config.Filenames {
+		file, err := input.Open(path)
+		if err != nil {
+			out.Flush()
+			fmt.Fprintf(os.Stderr, "nl: %v\n", input.OpenError(path, err))
+			ok = false
+			continue
+		}
+		if err := n.numberFile(file); err != nil {
+			out.Flush()
+			fmt.Fprintf(os.Stderr, "nl: %v\n", input.ReadError(path, err))
+			ok = false
+		}
+		file.Close()
+	}
+
+	if !ok {
+		out.Flush()
+		os.Exit(1)
+	}
+}
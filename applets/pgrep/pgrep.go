@@ -0,0 +1,376 @@
+package pgrep
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/mir-yks/LinuxCommandAnalog/internal/applet"
+	"github.com/mir-yks/LinuxCommandAnalog/internal/getopt"
+	"github.com/mir-yks/LinuxCommandAnalog/internal/proc"
+	"github.com/mir-yks/LinuxCommandAnalog/internal/signals"
+)
+
+type Config struct {
+	Help       bool
+	Version    bool
+	Kill       bool           // запущено как pkill
+	Pattern    *regexp.Regexp // шаблон; nil - отбор только по ключам
+	Full       bool           // -f: шаблон проверяется по всей командной строке
+	Exact      bool           // -x: шаблон должен совпасть целиком
+	IgnoreCase bool           // -i
+	Inverse    bool           // -v: процессы, которые не подходят
+	UIDs       []int          // -u: действующие пользователи
+	Parents    []int          // -P: родители
+	Older      time.Duration  // --older: работают не меньше
+	Newer      time.Duration  // --newer: работают меньше; 0 - без ограничения
+	Newest     bool           // -n: только самый новый
+	Oldest     bool           // -o: только самый старый
+	ListName   bool           // -l: PID и имя
+	ListFull   bool           // -a: PID и командная строка
+	Count      bool           // -c: только число процессов
+	Delimiter  string         // -d: разделитель вывода pgrep
+	Signal     syscall.Signal // сигнал pkill
+	Echo       bool           // -e: pkill сообщает о каждом процессе
+}
+
+const ver = "1.0.0"
+
+// Коды возврата, как в procps: 1 - ни один процесс не подошел
+const (
+	exitNoMatch = 1
+	exitFailure = 3
+)
+
+func init() {
+	applet.Register("pgrep", Main)
+	applet.Register("pkill", KillMain)
+}
+
+// Main запускает утилиту pgrep
+func Main() {
+	run(false)
+}
+
+// KillMain запускает утилиту pkill
+func KillMain() {
+	run(true)
+}
+
+// run - общая часть pgrep и pkill
+func run(kill bool) {
+	name := "pgrep"
+	if kill {
+		name = "pkill"
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			fmt.Fprintf(os.Stderr, "%s: %v\n", name, r)
+			os.Exit(exitFailure)
+		}
+	}()
+
+	config := parseArgs(name, kill)
+
+	if config.Help {
+		printHelp(name)
+		return
+	}
+
+	if config.Version {
+		printVersion(name)
+		return
+	}
+
+	matches, err := findProcesses(config)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%s: %v\n", name, err)
+		os.Exit(exitFailure)
+	}
+
+	if config.Kill {
+		if !signalProcesses(matches, config) {
+			os.Exit(exitNoMatch)
+		}
+		return
+	}
+	printProcesses(matches, config)
+	if len(matches) == 0 {
+		os.Exit(exitNoMatch)
+	}
+}
+
+// parseArgs разбирает аргументы командной строки. pkill, как kill,
+// принимает сигнал первым аргументом: pkill -HUP nginx.
+func parseArgs(name string, kill bool) *Config {
+	config := &Config{Kill: kill, Delimiter: "\n", Signal: syscall.SIGTERM}
+	args := os.Args[1:]
+
+	if kill && len(args) > 0 && len(args[0]) > 1 && args[0][0] == '-' && args[0][1] != '-' {
+		if sig, err := signals.Parse(args[0][1:]); err == nil {
+			config.Signal = sig
+			args = args[1:]
+		}
+	}
+
+	opts := getopt.New(name)
+	opts.Bool(&config.Help, 'h', "help")
+	opts.Bool(&config.Version, 'V', "version")
+	opts.Bool(&config.Full, 'f', "full")
+	opts.Bool(&config.Exact, 'x', "exact")
+	opts.Bool(&config.IgnoreCase, 'i', "ignore-case")
+	opts.Bool(&config.Inverse, 'v', "inverse")
+	opts.Bool(&config.Newest, 'n', "newest")
+	opts.Bool(&config.Oldest, 'o', "oldest")
+	opts.Bool(&config.Count, 'c', "count")
+	opts.Func('u', "euid", getopt.RequiredArgument, func(value string) error {
+		for _, item := range proc.SplitList(value) {
+			uid, err := proc.LookupUID(item)
+			if err != nil {
+				return err
+			}
+			config.UIDs = append(config.UIDs, uid)
+		}
+		return nil
+	})
+	opts.Func('P', "parent", getopt.RequiredArgument, func(value string) error {
+		for _, item := range proc.SplitList(value) {
+			pid, err := strconv.Atoi(item)
+			if err != nil || pid < 0 {
+				return fmt.Errorf("неверный PID '%s'", item)
+			}
+			config.Parents = append(config.Parents, pid)
+		}
+		return nil
+	})
+	opts.Func('O', "older", getopt.RequiredArgument, func(value string) error {
+		d, err := parseAge(value)
+		config.Older = d
+		return err
+	})
+	opts.Func(0, "newer", getopt.RequiredArgument, func(value string) error {
+		d, err := parseAge(value)
+		config.Newer = d
+		return err
+	})
+	if kill {
+		opts.Func(0, "signal", getopt.RequiredArgument, func(value string) error {
+			sig, err := signals.Parse(value)
+			config.Signal = sig
+			return err
+		})
+		opts.Bool(&config.Echo, 'e', "echo")
+	} else {
+		opts.Bool(&config.ListName, 'l', "list-name")
+		opts.Bool(&config.ListFull, 'a', "list-full")
+		opts.String(&config.Delimiter, 'd', "delimiter")
+	}
+	args = opts.Parse(args)
+
+	if config.Help || config.Version {
+		return config
+	}
+	if len(args) > 1 {
+		opts.Failf("допускается только один шаблон; лишний аргумент '%s'", args[1])
+	}
+	if config.Newest && config.Oldest {
+		opts.Failf("-n и -o нельзя использовать вместе")
+	}
+	if len(args) == 1 {
+		pattern := args[0]
+		if config.Exact {
+			pattern = "^(?:" + pattern + ")$"
+		}
+		if config.IgnoreCase {
+			pattern = "(?i)" + pattern
+		}
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			opts.Failf("неверный шаблон '%s': %v", args[0], err)
+		}
+		config.Pattern = re
+	} else if len(config.UIDs) == 0 && len(config.Parents) == 0 && config.Older == 0 && config.Newer == 0 {
+		opts.Failf("не задан ни шаблон, ни условие отбора")
+	}
+	return config
+}
+
+// parseAge разбирает возраст процесса в секундах
+func parseAge(value string) (time.Duration, error) {
+	seconds, err := strconv.ParseUint(value, 10, 32)
+	if err != nil {
+		return 0, fmt.Errorf("неверное число секунд '%s'", value)
+	}
+	return time.Duration(seconds) * time.Second, nil
+}
+
+// printHelp выводит справку
+func printHelp(name string) {
+	if name == "pkill" {
+		fmt.Println("pkill - отправляет сигнал процессам, найденным по имени и другим признакам")
+		fmt.Println()
+		fmt.Println("Использование: pkill [-СИГНАЛ] [ОПЦИЯ]... [ШАБЛОН]")
+	} else {
+		fmt.Println("pgrep - ищет процессы по имени и другим признакам")
+		fmt.Println()
+		fmt.Println("Использование: pgrep [ОПЦИЯ]... [ШАБЛОН]")
+	}
+	fmt.Println()
+	fmt.Println("ШАБЛОН - регулярное выражение; оно ищется в имени процесса (не длиннее")
+	fmt.Println("15 символов, как в /proc/PID/comm), а с -f - во всей командной строке.")
+	fmt.Println("Все условия должны выполняться одновременно. Сам процесс в выборку не")
+	fmt.Println("попадает.")
+	fmt.Println()
+	fmt.Println("Опции:")
+	if name == "pkill" {
+		fmt.Println("  -СИГНАЛ, --signal=СИГНАЛ")
+		fmt.Println("                        сигнал: номер или имя (по умолчанию TERM)")
+		fmt.Println("  -e, --echo            сообщать о каждом процессе, получившем сигнал")
+	} else {
+		fmt.Println("  -l, --list-name       выводить PID и имя процесса")
+		fmt.Println("  -a, --list-full       выводить PID и командную строку")
+		fmt.Println("  -d, --delimiter=СТР   разделитель PID (по умолчанию перевод строки)")
+	}
+	fmt.Println("  -c, --count           вывести только число найденных процессов")
+	fmt.Println("  -f, --full            искать шаблон во всей командной строке")
+	fmt.Println("  -x, --exact           шаблон должен совпасть целиком")
+	fmt.Println("  -i, --ignore-case     не различать регистр")
+	fmt.Println("  -v, --inverse         выбрать процессы, которые не подходят")
+	fmt.Println("  -u, --euid=СПИСОК     только процессы пользователей: -u root,1000")
+	fmt.Println("  -P, --parent=СПИСОК   только дети указанных процессов")
+	fmt.Println("  -O, --older=СЕК       только процессы, работающие не меньше СЕК секунд")
+	fmt.Println("      --newer=СЕК       только процессы, работающие меньше СЕК секунд")
+	fmt.Println("  -n, --newest          только самый новый из найденных")
+	fmt.Println("  -o, --oldest          только самый старый из найденных")
+	fmt.Println("  -h, --help            показать эту справку")
+	fmt.Println("  -V, --version         показать информацию о версии")
+	fmt.Println()
+	fmt.Println("Код возврата: 0 - процессы найдены, 1 - не найдены, 2 - ошибка в")
+	fmt.Println("аргументах, 3 - другая ошибка.")
+	fmt.Println()
+	fmt.Println("Примеры:")
+	if name == "pkill" {
+		fmt.Println("  pkill sleep                  # TERM всем sleep")
+		fmt.Println("  pkill -HUP -x nginx          # Перечитать настройки nginx")
+		fmt.Println("  pkill -9 -f 'python.*bot'    # По командной строке")
+		fmt.Println("  pkill -u alice --older 3600  # Процессы alice старше часа")
+	} else {
+		fmt.Println("  pgrep sshd                   # PID всех sshd")
+		fmt.Println("  pgrep -l -u root bash        # PID и имена bash пользователя root")
+		fmt.Println("  pgrep -f 'java .*-jar app'   # По командной строке")
+		fmt.Println("  pgrep -n -x nginx            # Самый новый nginx")
+		fmt.Println("  pgrep -P 1 -c                # Сколько детей у init")
+	}
+}
+
+// printVersion выводит информацию о версии
+func printVersion(name string) {
+	fmt.Println(name, "версия", ver)
+	fmt.Println("Разработано в рамках учебного проекта")
+	fmt.Println("Язык программирования: Golang")
+}
+
+// findProcesses возвращает процессы, подходящие под все условия, в порядке PID
+func findProcesses(config *Config) ([]*proc.Process, error) {
+	procs, err := proc.List()
+	if err != nil {
+		return nil, err
+	}
+	sys, err := proc.ReadSystem()
+	if err != nil {
+		return nil, err
+	}
+
+	self := os.Getpid()
+	var matches []*proc.Process
+	for _, p := range procs {
+		if p.PID != self && match(config, p, sys) != config.Inverse {
+			matches = append(matches, p)
+		}
+	}
+
+	if (config.Newest || config.Oldest) && len(matches) > 0 {
+		// Из процессов, запущенных в один такт, выбирается больший PID
+		// для -n и меньший для -o
+		pick := matches[0]
+		for _, p := range matches[1:] {
+			if config.Newest && p.Start >= pick.Start || config.Oldest && p.Start < pick.Start {
+				pick = p
+			}
+		}
+		matches = []*proc.Process{pick}
+	}
+	return matches, nil
+}
+
+// match проверяет условия отбора
+func match(config *Config, p *proc.Process, sys *proc.System) bool {
+	if len(config.UIDs) > 0 && !slices.Contains(config.UIDs, p.UID) {
+		return false
+	}
+	if len(config.Parents) > 0 && !slices.Contains(config.Parents, p.PPID) {
+		return false
+	}
+	age := p.Elapsed(sys)
+	if config.Older > 0 && age < config.Older {
+		return false
+	}
+	if config.Newer > 0 && age >= config.Newer {
+		return false
+	}
+	if config.Pattern != nil {
+		subject := p.Name
+		if config.Full && len(p.Cmdline) > 0 {
+			subject = strings.Join(p.Cmdline, " ")
+		}
+		return config.Pattern.MatchString(subject)
+	}
+	return true
+}
+
+// printProcesses выводит найденные процессы, как pgrep
+func printProcesses(list []*proc.Process, config *Config) {
+	if config.Count {
+		fmt.Println(len(list))
+		return
+	}
+	items := make([]string, len(list))
+	for i, p := range list {
+		switch {
+		case config.ListFull:
+			items[i] = fmt.Sprintf("%d %s", p.PID, p.Command())
+		case config.ListName:
+			items[i] = fmt.Sprintf("%d %s", p.PID, p.Name)
+		default:
+			items[i] = strconv.Itoa(p.PID)
+		}
+	}
+	if len(items) > 0 {
+		fmt.Print(strings.Join(items, config.Delimiter) + "\n")
+	}
+}
+
+// signalProcesses отправляет сигнал найденным процессам; false - если ни
+// один процесс его не получил
+func signalProcesses(list []*proc.Process, config *Config) bool {
+	sent := 0
+	for _, p := range list {
+		if err := syscall.Kill(p.PID, config.Signal); err != nil {
+			fmt.Fprintf(os.Stderr, "pkill: не удалось отправить сигнал процессу %d (%s): %v\n", p.PID, p.Name, err)
+			continue
+		}
+		sent++
+		if config.Echo {
+			fmt.Printf("%s (pid %d): отправлен сигнал %d\n", p.Name, p.PID, config.Signal)
+		}
+	}
+	if config.Count {
+		fmt.Println(sent)
+	}
+	return sent > 0
+}
@@ -0,0 +1,379 @@
+package ps
+
+import (
+	"cmp"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mir-yks/LinuxCommandAnalog/internal/proc"
+)
+
+// process - процесс вместе с общими сведениями о системе, нужными полям
+type process struct {
+	*proc.Process
+	sys    *proc.System
+	now    time.Time
+	prefix string // линии дерева перед командой в режиме --forest
+}
+
+// field описывает столбец, который можно выбрать в -o и --sort
+type field struct {
+	header  string
+	width   int  // наименьшая ширина столбца, как в procps
+	left    bool // текстовый столбец выравнивается по левому краю
+	format  func(p *process) string
+	compare func(a, b *process) int
+}
+
+// byInt, byFloat и byString строят функцию сравнения по значению поля
+func byInt(value func(p *process) int64) func(a, b *process) int {
+	return func(a, b *process) int { return cmp.Compare(value(a), value(b)) }
+}
+
+func byFloat(value func(p *process) float64) func(a, b *process) int {
+	return func(a, b *process) int { return cmp.Compare(value(a), value(b)) }
+}
+
+func byString(value func(p *process) string) func(a, b *process) int {
+	return func(a, b *process) int { return strings.Compare(value(a), value(b)) }
+}
+
+// intField - числовой столбец, выровненный по правому краю
+func intField(header string, width int, value func(p *process) int64) *field {
+	return &field{
+		header:  header,
+		width:   width,
+		format:  func(p *process) string { return strconv.FormatInt(value(p), 10) },
+		compare: byInt(value),
+	}
+}
+
+// textField - текстовый столбец, выровненный по левому краю
+func textField(header string, width int, value func(p *process) string) *field {
+	return &field{header: header, width: width, left: true, format: value, compare: byString(value)}
+}
+
+var (
+	pidField  = intField("PID", 5, func(p *process) int64 { return int64(p.PID) })
+	ppidField = intField("PPID", 5, func(p *process) int64 { return int64(p.PPID) })
+	pgidField = intField("PGID", 5, func(p *process) int64 { return int64(p.PGID) })
+	sidField  = intField("SID", 5, func(p *process) int64 { return int64(p.SID) })
+	uidField  = intField("UID", 5, func(p *process) int64 { return int64(p.UID) })
+	userField = textField("USER", 8, func(p *process) string { return proc.UserName(p.UID) })
+
+	cpuField = &field{
+		header:  "%CPU",
+		width:   4,
+		format:  func(p *process) string { return formatPercent(p.CPUPercent(p.sys)) },
+		compare: byFloat(func(p *process) float64 { return p.CPUPercent(p.sys) }),
+	}
+	memField = &field{
+		header:  "%MEM",
+		width:   4,
+		format:  func(p *process) string { return formatPercent(p.MemPercent(p.sys)) },
+		compare: byInt(func(p *process) int64 { return int64(p.RSS) }),
+	}
+	// C - загрузка процессора целым числом, как в ps -f
+	cField = intField("C", 2, func(p *process) int64 {
+		return int64(min(p.CPUPercent(p.sys), 99))
+	})
+	vszField  = intField("VSZ", 6, func(p *process) int64 { return int64(p.VSize / 1024) })
+	rssField  = intField("RSS", 5, func(p *process) int64 { return int64(p.RSS / 1024) })
+	niceField = intField("NI", 3, func(p *process) int64 { return int64(p.Nice) })
+	nlwpField = intField("NLWP", 4, func(p *process) int64 { return int64(p.Threads) })
+
+	// Терминал в procps озаглавлен TT у tty и tt и TTY у tname, который
+	// и используется в стандартных форматах
+	ttyName    = func(p *process) string { return proc.TTYName(p.TTY) }
+	ttyField   = textField("TT", 8, ttyName)
+	tnameField = textField("TTY", 8, ttyName)
+	statField  = textField("STAT", 4, func(p *process) string { return p.StatString() })
+	stateField = textField("S", 1, func(p *process) string { return string(p.State) })
+
+	// Время запуска в procps выводится четырьмя способами: start с
+	// секундами, start_time (stime) с точностью до минуты, дня или года,
+	// bsdstart в стиле BSD и полная дата lstart
+	started    = byInt(func(p *process) int64 { return int64(p.Start) })
+	startField = &field{
+		header:  "STARTED",
+		width:   8,
+		format:  func(p *process) string { return formatStart(p.StartTime(p.sys), p.now) },
+		compare: started,
+	}
+	startTimeField = &field{
+		header:  "START",
+		width:   5,
+		left:    true,
+		format:  func(p *process) string { return formatStartTime(p.StartTime(p.sys), p.now) },
+		compare: started,
+	}
+	stimeField = &field{
+		header:  "STIME",
+		width:   5,
+		left:    true,
+		format:  startTimeField.format,
+		compare: started,
+	}
+	bsdStartField = &field{
+		header:  "START",
+		width:   6,
+		format:  func(p *process) string { return formatBSDStart(p.StartTime(p.sys), p.now) },
+		compare: started,
+	}
+	lstartField = &field{
+		header:  "STARTED",
+		width:   24,
+		format:  func(p *process) string { return p.StartTime(p.sys).Format("Mon Jan _2 15:04:05 2006") },
+		compare: started,
+	}
+
+	cpuTime   = func(p *process) int64 { return int64(p.UTime + p.STime) }
+	timeField = &field{
+		header:  "TIME",
+		width:   8,
+		format:  func(p *process) string { return formatTime(p.CPUTime(p.sys)) },
+		compare: byInt(cpuTime),
+	}
+	bsdTimeField = &field{
+		header:  "TIME",
+		width:   6,
+		format:  func(p *process) string { return formatShortTime(p.CPUTime(p.sys)) },
+		compare: byInt(cpuTime),
+	}
+
+	// Время работы сравнивается по моменту запуска в обратном порядке
+	elapsed    = func(p *process) int64 { return -int64(p.Start) }
+	etimeField = &field{
+		header:  "ELAPSED",
+		width:   11,
+		format:  func(p *process) string { return formatElapsed(p.Elapsed(p.sys)) },
+		compare: byInt(elapsed),
+	}
+	etimesField = &field{
+		header:  "ELAPSED",
+		width:   7,
+		format:  func(p *process) string { return strconv.FormatInt(int64(p.Elapsed(p.sys)/time.Second), 10) },
+		compare: byInt(elapsed),
+	}
+
+	argsField = textField("COMMAND", 0, func(p *process) string { return p.prefix + proc.Printable(p.Command()) })
+	commField = textField("COMMAND", 0, func(p *process) string { return p.prefix + proc.Printable(p.Name) })
+)
+
+// fields - поля для -o и --sort; у многих есть синонимы из procps
+var fields = map[string]*field{
+	"pid":        pidField,
+	"ppid":       ppidField,
+	"pgid":       pgidField,
+	"pgrp":       pgidField,
+	"sid":        sidField,
+	"sess":       sidField,
+	"uid":        uidField,
+	"euid":       uidField,
+	"user":       userField,
+	"euser":      userField,
+	"uname":      userField,
+	"%cpu":       cpuField,
+	"pcpu":       cpuField,
+	"c":          cField,
+	"%mem":       memField,
+	"pmem":       memField,
+	"vsz":        vszField,
+	"vsize":      vszField,
+	"rss":        rssField,
+	"rssize":     rssField,
+	"ni":         niceField,
+	"nice":       niceField,
+	"nlwp":       nlwpField,
+	"thcount":    nlwpField,
+	"tty":        ttyField,
+	"tt":         ttyField,
+	"tname":      tnameField,
+	"stat":       statField,
+	"s":          stateField,
+	"state":      stateField,
+	"start":      startField,
+	"start_time": startTimeField,
+	"stime":      stimeField,
+	"bsdstart":   bsdStartField,
+	"lstart":     lstartField,
+	"time":       timeField,
+	"cputime":    timeField,
+	"bsdtime":    bsdTimeField,
+	"etime":      etimeField,
+	"etimes":     etimesField,
+	"args":       argsField,
+	"cmd":        argsField,
+	"command":    argsField,
+	"comm":       commField,
+	"ucmd":       commField,
+	"ucomm":      commField,
+}
+
+// column - выбранный столбец и его заголовок
+type column struct {
+	field  *field
+	header string
+}
+
+// Стандартные наборы столбцов; каждый элемент записывается так же, как
+// аргумент -o
+var (
+	defaultFormat = []string{"pid", "tname", "time", "comm=CMD"}
+	fullFormat    = []string{"user=UID", "pid", "ppid", "c", "stime", "tname", "time", "args=CMD"}
+	userFormat    = []string{"user", "pid", "%cpu", "%mem", "vsz", "rss", "tname", "stat", "start_time", "bsdtime", "args"}
+	statFormat    = []string{"pid", "tname", "stat", "bsdtime", "args"}
+)
+
+// layout собирает стандартный набор столбцов
+func layout(items []string) []column {
+	var columns []column
+	for _, item := range items {
+		col, err := parseFormat(item)
+		if err != nil {
+			panic(err)
+		}
+		columns = append(columns, col...)
+	}
+	return columns
+}
+
+// parseFormat разбирает список полей: "pid,user" или "pid user". После
+// "=" задается свой заголовок; как в procps, он продолжается до конца
+// аргумента, поэтому может содержать запятые: -o pid,args=КОМАНДА, ПАРАМЕТРЫ.
+// Пустой заголовок (pid=) скрывает название столбца.
+func parseFormat(spec string) ([]column, error) {
+	var columns []column
+	for spec != "" {
+		spec = strings.TrimLeft(spec, ", ")
+		if spec == "" {
+			break
+		}
+		end := strings.IndexAny(spec, ", =")
+		if end < 0 {
+			end = len(spec)
+		}
+		name := spec[:end]
+		f, ok := fields[strings.ToLower(name)]
+		if !ok {
+			return nil, fmt.Errorf("неизвестное поле '%s'", name)
+		}
+		col := column{field: f, header: f.header}
+		if end < len(spec) && spec[end] == '=' {
+			// Пустой заголовок не поглощает остаток: -o pid=,comm=
+			if end+1 < len(spec) && spec[end+1] != ',' {
+				col.header = spec[end+1:]
+				end = len(spec)
+			} else {
+				col.header = ""
+				end++
+			}
+		}
+		columns = append(columns, col)
+		spec = spec[end:]
+	}
+	if len(columns) == 0 {
+		return nil, fmt.Errorf("пустой список полей")
+	}
+	return columns, nil
+}
+
+// sortKey - поле сортировки и направление
+type sortKey struct {
+	field *field
+	desc  bool
+}
+
+// parseSort разбирает --sort: поля через запятую, "-" перед именем -
+// по убыванию, "+" или ничего - по возрастанию
+func parseSort(spec string) ([]sortKey, error) {
+	var keys []sortKey
+	for _, item := range strings.Split(spec, ",") {
+		key := sortKey{}
+		switch {
+		case strings.HasPrefix(item, "-"):
+			key.desc = true
+			item = item[1:]
+		case strings.HasPrefix(item, "+"):
+			item = item[1:]
+		}
+		f, ok := fields[strings.ToLower(item)]
+		if !ok {
+			return nil, fmt.Errorf("неизвестное поле сортировки '%s'", item)
+		}
+		key.field = f
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
+
+// formatPercent выводит процент с одним знаком, отбрасывая остаток, как procps
+func formatPercent(v float64) string {
+	return strconv.FormatFloat(math.Floor(v*10)/10, 'f', 1, 64)
+}
+
+// formatStart выводит время запуска для start, как procps: время
+// с секундами для процессов младше суток, иначе месяц и день
+func formatStart(started, now time.Time) string {
+	if now.Sub(started) < 24*time.Hour {
+		return started.Format("15:04:05")
+	}
+	return started.Format("Jan 02")
+}
+
+// formatBSDStart выводит время запуска для bsdstart: часы и минуты для
+// процессов младше суток, иначе месяц и день
+func formatBSDStart(started, now time.Time) string {
+	if now.Sub(started) < 24*time.Hour {
+		return started.Format("15:04")
+	}
+	return started.Format("Jan _2")
+}
+
+// formatStartTime выводит время запуска для start_time и stime, как
+// procps: часы и минуты для процессов младше суток, месяц и день - младше
+// года, иначе год
+func formatStartTime(started, now time.Time) string {
+	age := now.Sub(started)
+	switch {
+	case age < 24*time.Hour:
+		return started.Format("15:04")
+	case age < 365*24*time.Hour:
+		return started.Format("Jan02")
+	}
+	return started.Format("2006")
+}
+
+// formatTime выводит процессорное время в виде [ДД-]ЧЧ:ММ:СС
+func formatTime(d time.Duration) string {
+	seconds := int64(d / time.Second)
+	days := seconds / 86400
+	s := fmt.Sprintf("%02d:%02d:%02d", seconds/3600%24, seconds/60%60, seconds%60)
+	if days > 0 {
+		s = fmt.Sprintf("%d-%s", days, s)
+	}
+	return s
+}
+
+// formatShortTime выводит процессорное время в виде М:СС, как столбец TIME в ps aux
+func formatShortTime(d time.Duration) string {
+	seconds := int64(d / time.Second)
+	return fmt.Sprintf("%d:%02d", seconds/60, seconds%60)
+}
+
+// formatElapsed выводит время работы в виде [[ДД-]ЧЧ:]ММ:СС
+func formatElapsed(d time.Duration) string {
+	seconds := int64(d / time.Second)
+	days, hours := seconds/86400, seconds/3600%24
+	s := fmt.Sprintf("%02d:%02d", seconds/60%60, seconds%60)
+	switch {
+	case days > 0:
+		s = fmt.Sprintf("%d-%02d:%s", days, hours, s)
+	case hours > 0:
+		s = fmt.Sprintf("%02d:%s", hours, s)
+	}
+	return s
+}
@@ -0,0 +1,43 @@
+package ps
+
+import "github.com/mir-yks/LinuxCommandAnalog/internal/proc"
+
+// arrangeForest упорядочивает процессы для --forest: каждый родитель идет
+// перед своими детьми, а дети получают префикс с линиями, как в procps:
+//
+//	/usr/sbin/sshd
+//	 \_ sshd: alice
+//	 |   \_ -bash
+//	 \_ sshd: bob
+//
+// Соседи сохраняют порядок сортировки. Корни - процессы, родителя которых
+// нет среди выбранных.
+func arrangeForest(list []*process) []*process {
+	procs := make([]*proc.Process, len(list))
+	byPID := make(map[int]*process, len(list))
+	for i, p := range list {
+		procs[i] = p.Process
+		byPID[p.PID] = p
+	}
+	children := proc.Children(procs)
+
+	result := make([]*process, 0, len(list))
+	var visit func(p *process, indent string)
+	visit = func(p *process, indent string) {
+		result = append(result, p)
+		kids := children[p.PID]
+		for i, child := range kids {
+			c := byPID[child.PID]
+			c.prefix = indent + " \\_ "
+			if i < len(kids)-1 {
+				visit(c, indent+" |  ")
+			} else {
+				visit(c, indent+"    ")
+			}
+		}
+	}
+	for _, root := range proc.Roots(procs) {
+		visit(byPID[root.PID], "")
+	}
+	return result
+}
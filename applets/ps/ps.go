@@ -0,0 +1,388 @@
+package ps
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"os/user"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mir-yks/LinuxCommandAnalog/internal/applet"
+	"github.com/mir-yks/LinuxCommandAnalog/internal/display"
+	"github.com/mir-yks/LinuxCommandAnalog/internal/getopt"
+	"github.com/mir-yks/LinuxCommandAnalog/internal/proc"
+	"github.com/mir-yks/LinuxCommandAnalog/internal/term"
+)
+
+type Config struct {
+	Help      bool
+	All       bool     // -e, -A, -a, BSD ax: все процессы
+	WithTTY   bool     // BSD a без x: процессы с терминалом
+	Users     []string // -u, ПОЛЬЗОВАТЕЛЬ: процессы пользователей
+	PIDs      []int    // -p
+	Commands  []string // -C: по имени процесса
+	PPIDs     []int    // --ppid: по родителю
+	Columns   []column
+	Sort      []sortKey
+	NoHeaders bool
+	Wide      bool // BSD w: не обрезать строки по ширине терминала
+	Forest    bool // --forest, BSD f: дерево процессов
+}
+
+const version = "1.0.0"
+
+func init() {
+	applet.Register("ps", Main)
+}
+
+// Main запускает утилиту ps
+func Main() {
+	defer func() {
+		if r := recover(); r != nil {
+			fmt.Fprintf(os.Stderr, "ps: критическая ошибка: %v\n", r)
+			os.Exit(1)
+		}
+	}()
+
+	config := parseArgs()
+
+	if config.Help {
+		printHelp()
+		return
+	}
+
+	list, err := fetchProcesses(config)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "ps: %v\n", err)
+		os.Exit(1)
+	}
+
+	sortProcesses(list, config.Sort)
+	if config.Forest {
+		list = arrangeForest(list)
+	}
+	displayProcesses(list, config)
+	// Как в procps, пустой результат выбора - код 1
+	if len(list) == 0 {
+		os.Exit(1)
+	}
+}
+
+// bsdLetters - ключи BSD, которые пишутся без дефиса: ps aux
+const bsdLetters = "auxwf"
+
+// parseArgs разбирает аргументы командной строки
+func parseArgs() *Config {
+	config := &Config{}
+	var format []string
+	var full, userLayout, statLayout, selectUser bool
+	var sortSpec string
+
+	opts := getopt.New("ps")
+	opts.Bool(&config.Help, 'h', "help")
+	opts.Bool(&config.All, 'e', "")
+	opts.Bool(&config.All, 'A', "")
+	opts.Bool(&config.All, 'a', "")
+	opts.Func('g', "", getopt.NoArgument, func(string) error {
+		config.All, statLayout = true, true
+		return nil
+	})
+	opts.Func('u', "user", getopt.OptionalArgument, func(value string) error {
+		selectUser, userLayout = true, true
+		if value != "" {
+			config.Users = append(config.Users, proc.SplitList(value)...)
+		}
+		return nil
+	})
+	opts.Func('p', "pid", getopt.RequiredArgument, func(value string) error {
+		return appendPIDs(&config.PIDs, value)
+	})
+	opts.Func(0, "ppid", getopt.RequiredArgument, func(value string) error {
+		return appendPIDs(&config.PPIDs, value)
+	})
+	opts.Func('C', "", getopt.RequiredArgument, func(value string) error {
+		config.Commands = append(config.Commands, proc.SplitList(value)...)
+		return nil
+	})
+	opts.Bool(&full, 'f', "")
+	opts.Strings(&format, 'o', "format")
+	opts.String(&sortSpec, 0, "sort")
+	opts.Bool(&config.NoHeaders, 0, "no-headers")
+	opts.Bool(&config.Forest, 0, "forest")
+	args := opts.Parse(os.Args[1:])
+
+	// Операнды из букв aux - ключи BSD, остальные - имена пользователей
+	for _, arg := range args {
+		if strings.Trim(arg, bsdLetters) != "" {
+			selectUser = true
+			config.Users = append(config.Users, arg)
+			continue
+		}
+		if strings.ContainsRune(arg, 'u') {
+			userLayout = true
+		}
+		if strings.ContainsRune(arg, 'w') {
+			config.Wide = true
+		}
+		if strings.ContainsRune(arg, 'f') {
+			config.Forest = true
+		}
+		hasA, hasX := strings.ContainsRune(arg, 'a'), strings.ContainsRune(arg, 'x')
+		switch {
+		case hasA && hasX:
+			config.All = true
+		case hasA:
+			config.WithTTY = true
+		case hasX:
+			selectUser = true
+		}
+		if (hasA || hasX) && !strings.ContainsRune(arg, 'u') {
+			statLayout = true
+		}
+	}
+	if selectUser && len(config.Users) == 0 {
+		config.Users = []string{currentUser()}
+	}
+
+	// Каждый -o разбирается отдельно: заголовок после "=" идет до конца аргумента
+	for _, spec := range format {
+		columns, err := parseFormat(spec)
+		if err != nil {
+			opts.Failf("%v", err)
+		}
+		config.Columns = append(config.Columns, columns...)
+	}
+
+	if len(config.Columns) == 0 {
+		switch {
+		case full:
+			config.Columns = layout(fullFormat)
+		case userLayout:
+			config.Columns = layout(userFormat)
+		case statLayout:
+			config.Columns = layout(statFormat)
+		default:
+			config.Columns = layout(defaultFormat)
+		}
+	}
+
+	if sortSpec != "" {
+		var err error
+		if config.Sort, err = parseSort(sortSpec); err != nil {
+			opts.Failf("%v", err)
+		}
+	}
+
+	return config
+}
+
+// appendPIDs добавляет номера процессов из списка
+func appendPIDs(list *[]int, value string) error {
+	for _, item := range proc.SplitList(value) {
+		pid, err := strconv.Atoi(item)
+		if err != nil || pid < 0 {
+			return fmt.Errorf("неверный номер процесса '%s'", item)
+		}
+		*list = append(*list, pid)
+	}
+	return nil
+}
+
+// currentUser возвращает имя пользователя, запустившего ps
+func currentUser() string {
+	if u, err := user.Current(); err == nil {
+		return u.Username
+	}
+	return strconv.Itoa(os.Geteuid())
+}
+
+func printHelp() {
+	fmt.Println("ps - отображает информацию о процессах")
+	fmt.Println()
+	fmt.Println("Использование: ps [ОПЦИЯ]... [ПОЛЬЗОВАТЕЛЬ]...")
+	fmt.Println()
+	fmt.Println("Без опций выводятся процессы текущего пользователя. Условия выбора")
+	fmt.Println("объединяются: -p 1 -C sshd покажет и процесс 1, и все sshd.")
+	fmt.Println()
+	fmt.Println("Выбор процессов:")
+	fmt.Println("  -e, -A, -a        все процессы")
+	fmt.Println("  -u[ПОЛЬЗОВАТЕЛЬ]  процессы пользователя (по умолчанию текущего)")
+	fmt.Printf("                    в формате USER PID %%CPU %%MEM ...\n")
+	fmt.Println("  -p СПИСОК         процессы с указанными PID: -p 1,42")
+	fmt.Println("  -C СПИСОК         процессы с указанными именами: -C bash,sshd")
+	fmt.Println("      --ppid СПИСОК дочерние процессы указанных PID")
+	fmt.Println()
+	fmt.Println("Формат вывода:")
+	fmt.Println("  -f                полный формат: UID PID PPID C STIME TTY TIME CMD")
+	fmt.Println("  -g                все процессы в формате PID TTY STAT TIME COMMAND")
+	fmt.Printf("  -o СПИСОК         свои столбцы: -o pid,ppid,user,%%cpu,rss,etime,args;\n")
+	fmt.Println("                    поле=ЗАГОЛОВОК задает заголовок столбца")
+	fmt.Println("      --sort СПИСОК сортировка по полям; -поле - по убыванию: --sort=-rss")
+	fmt.Println("      --forest      дерево процессов: дети под родителями с отступом \\_")
+	fmt.Println("      --no-headers  не выводить заголовок")
+	fmt.Println("  -h, --help        показать эту справку")
+	fmt.Println()
+	fmt.Println("Ключи BSD (без дефиса): a - процессы с терминалом, x - процессы без")
+	fmt.Printf("терминала, u - формат с USER и %%CPU, w - не обрезать строки,\n")
+	fmt.Println("f - дерево процессов.")
+	fmt.Println()
+	fmt.Printf("Поля: pid ppid pgid sid uid user %%cpu %%mem c vsz rss ni nlwp tty tname stat s\n")
+	fmt.Println("      start stime bsdstart lstart time bsdtime etime etimes args comm")
+	fmt.Println()
+	fmt.Println("Примеры:")
+	fmt.Println("  ps                    # процессы текущего пользователя")
+	fmt.Println("  ps -ef                # все процессы в полном формате")
+	fmt.Println("  ps aux                # все процессы с загрузкой и памятью")
+	fmt.Println("  ps -u root            # процессы пользователя root")
+	fmt.Println("  ps -e -o pid,rss,args --sort=-rss   # по убыванию памяти")
+	fmt.Println("  ps -C nginx -o pid=   # только PID процессов nginx")
+	fmt.Println("  ps -ef --forest       # иерархия процессов; также pstree")
+}
+
+// fetchProcesses читает процессы и оставляет выбранные
+func fetchProcesses(config *Config) ([]*process, error) {
+	procs, err := proc.List()
+	if err != nil {
+		return nil, err
+	}
+	sys, err := proc.ReadSystem()
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	var list []*process
+	for _, p := range procs {
+		if selected(config, p) {
+			list = append(list, &process{Process: p, sys: sys, now: now})
+		}
+	}
+	return list, nil
+}
+
+// selected проверяет условия выбора; процесс подходит, если выполнено
+// хотя бы одно из них. Без условий выбираются процессы текущего пользователя.
+func selected(config *Config, p *proc.Process) bool {
+	if config.All {
+		return true
+	}
+	if config.WithTTY && p.TTY != 0 {
+		return true
+	}
+	if slices.Contains(config.PIDs, p.PID) || slices.Contains(config.PPIDs, p.PPID) {
+		return true
+	}
+	if slices.Contains(config.Commands, p.Name) {
+		return true
+	}
+	for _, name := range config.Users {
+		if name == proc.UserName(p.UID) || name == strconv.Itoa(p.UID) {
+			return true
+		}
+	}
+
+	noSelection := !config.WithTTY && len(config.PIDs) == 0 && len(config.PPIDs) == 0 &&
+		len(config.Commands) == 0 && len(config.Users) == 0
+	return noSelection && p.UID == os.Geteuid()
+}
+
+// sortProcesses упорядочивает процессы по ключам --sort; по умолчанию по PID
+func sortProcesses(list []*process, keys []sortKey) {
+	sort.SliceStable(list, func(i, j int) bool {
+		for _, key := range keys {
+			c := key.field.compare(list[i], list[j])
+			if key.desc {
+				c = -c
+			}
+			if c != 0 {
+				return c < 0
+			}
+		}
+		return false
+	})
+}
+
+// displayProcesses выводит таблицу. Ширина столбца - по самому длинному
+// значению; последний столбец не дополняется пробелами и на терминале
+// обрезается по его ширине.
+func displayProcesses(list []*process, config *Config) {
+	columns := config.Columns
+	rows := make([][]string, 0, len(list)+1)
+
+	showHeader := !config.NoHeaders
+	if showHeader {
+		showHeader = false
+		header := make([]string, len(columns))
+		for i, col := range columns {
+			header[i] = col.header
+			showHeader = showHeader || col.header != ""
+		}
+		if showHeader {
+			rows = append(rows, header)
+		}
+	}
+	for _, p := range list {
+		row := make([]string, len(columns))
+		for i, col := range columns {
+			row[i] = col.field.format(p)
+		}
+		rows = append(rows, row)
+	}
+
+	widths := make([]int, len(columns))
+	for i, col := range columns {
+		widths[i] = col.field.width
+	}
+	for _, row := range rows {
+		for i, cell := range row {
+			widths[i] = max(widths[i], display.String(cell))
+		}
+	}
+
+	limit := 0
+	if !config.Wide && term.IsTerminal(os.Stdout.Fd()) {
+		limit = term.StdoutWidth()
+	}
+
+	out := bufio.NewWriter(os.Stdout)
+	defer out.Flush()
+	var line strings.Builder
+	for _, row := range rows {
+		line.Reset()
+		for i, cell := range row {
+			if i > 0 {
+				line.WriteByte(' ')
+			}
+			pad := strings.Repeat(" ", widths[i]-display.String(cell))
+			switch {
+			case !columns[i].field.left:
+				line.WriteString(pad + cell)
+			case i == len(row)-1:
+				line.WriteString(cell)
+			default:
+				line.WriteString(cell + pad)
+			}
+		}
+		text := line.String()
+		if limit > 0 && display.String(text) > limit {
+			text = truncate(text, limit)
+		}
+		out.WriteString(text + "\n")
+	}
+}
+
+// truncate обрезает строку до ширины width
+func truncate(s string, width int) string {
+	used := 0
+	for i, r := range s {
+		used += display.RuneWidth(r)
+		if used > width {
+			return s[:i]
+		}
+	}
+	return s
+}
@@ -0,0 +1,404 @@
+package pstree
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/mir-yks/LinuxCommandAnalog/internal/applet"
+	"github.com/mir-yks/LinuxCommandAnalog/internal/display"
+	"github.com/mir-yks/LinuxCommandAnalog/internal/getopt"
+	"github.com/mir-yks/LinuxCommandAnalog/internal/proc"
+	"github.com/mir-yks/LinuxCommandAnalog/internal/term"
+)
+
+type Config struct {
+	Help        bool
+	Version     bool
+	Args        bool // -a: командная строка, каждый процесс на своей строке
+	ShowPIDs    bool // -p: номера процессов (отключает сворачивание)
+	NoCompact   bool // -c: не сворачивать одинаковые поддеревья
+	HideThreads bool // -T: не показывать потоки
+	NumericSort bool // -n: сортировать по PID, а не по имени
+	Unicode     bool // -U или терминал с UTF-8; -A - ASCII
+	RootPID     int  // PID: дерево с корнем в этом процессе
+	User        string
+}
+
+const ver = "1.0.0"
+
+// lines - символы для рисования дерева
+type lines struct {
+	single, first, middle, last, bar string
+}
+
+var (
+	asciiLines   = lines{single: "---", first: "-+-", middle: " |-", last: " `-", bar: " | "}
+	unicodeLines = lines{single: "───", first: "─┬─", middle: " ├─", last: " └─", bar: " │ "}
+)
+
+func init() {
+	applet.Register("pstree", Main)
+}
+
+// Main запускает утилиту pstree
+func Main() {
+	defer func() {
+		if r := recover(); r != nil {
+			fmt.Fprintf(os.Stderr, "pstree: %v\n", r)
+			os.Exit(1)
+		}
+	}()
+
+	config := parseArgs()
+
+	if config.Help {
+		printHelp()
+		return
+	}
+
+	if config.Version {
+		printVersion()
+		return
+	}
+
+	if err := executePstree(config); err != nil {
+		fmt.Fprintf(os.Stderr, "pstree: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// parseArgs разбирает аргументы командной строки
+func parseArgs() *Config {
+	config := &Config{RootPID: 1, Unicode: unicodeTerminal()}
+
+	opts := getopt.New("pstree")
+	opts.Bool(&config.Help, 'h', "help")
+	opts.Bool(&config.Version, 'V', "version")
+	opts.Bool(&config.Args, 'a', "arguments")
+	opts.Bool(&config.ShowPIDs, 'p', "show-pids")
+	opts.Bool(&config.NoCompact, 'c', "compact-not")
+	opts.Bool(&config.HideThreads, 'T', "hide-threads")
+	opts.Bool(&config.NumericSort, 'n', "numeric-sort")
+	opts.Func('A', "ascii", getopt.NoArgument, func(string) error {
+		config.Unicode = false
+		return nil
+	})
+	opts.Func('U', "unicode", getopt.NoArgument, func(string) error {
+		config.Unicode = true
+		return nil
+	})
+	args := opts.Parse(os.Args[1:])
+
+	if len(args) > 1 {
+		opts.Failf("лишний аргумент '%s'", args[1])
+	}
+	if len(args) == 1 {
+		if pid, err := strconv.Atoi(args[0]); err == nil {
+			config.RootPID = pid
+		} else if _, err := proc.LookupUID(args[0]); err == nil {
+			config.User = args[0]
+		} else {
+			opts.Failf("нет такого пользователя '%s'", args[0])
+		}
+	}
+	// Номера делают узлы разными, сворачивать нечего
+	if config.ShowPIDs {
+		config.NoCompact = true
+	}
+
+	return config
+}
+
+// unicodeTerminal сообщает, что вывод идет на терминал с кодировкой UTF-8
+func unicodeTerminal() bool {
+	if !term.IsTerminal(os.Stdout.Fd()) {
+		return false
+	}
+	for _, name := range []string{"LC_ALL", "LC_CTYPE", "LANG"} {
+		if value := os.Getenv(name); value != "" {
+			value = strings.ToUpper(value)
+			return strings.Contains(value, "UTF-8") || strings.Contains(value, "UTF8")
+		}
+	}
+	return false
+}
+
+// printHelp выводит справку
+func printHelp() {
+	fmt.Println("pstree - показывает дерево процессов")
+	fmt.Println()
+	fmt.Println("Использование: pstree [ОПЦИЯ]... [PID|ПОЛЬЗОВАТЕЛЬ]")
+	fmt.Println()
+	fmt.Println("По умолчанию дерево строится от процесса 1. Одинаковые поддеревья")
+	fmt.Println("сворачиваются: 3*[sleep] - три процесса sleep с общим родителем.")
+	fmt.Println("Потоки показываются в фигурных скобках: {имя}.")
+	fmt.Println()
+	fmt.Println("Опции:")
+	fmt.Println("  -a, --arguments     показать командные строки; процесс на строке")
+	fmt.Println("  -p, --show-pids     показать PID (включает -c)")
+	fmt.Println("  -c, --compact-not   не сворачивать одинаковые поддеревья")
+	fmt.Println("  -n, --numeric-sort  сортировать по PID, а не по имени")
+	fmt.Println("  -T, --hide-threads  не показывать потоки")
+	fmt.Println("  -A, --ascii         линии из символов ASCII")
+	fmt.Println("  -U, --unicode       линии из символов Unicode (по умолчанию на")
+	fmt.Println("                      терминале с UTF-8)")
+	fmt.Println("  -h, --help          показать эту справку")
+	fmt.Println("  -V, --version       показать информацию о версии")
+	fmt.Println()
+	fmt.Println("Примеры:")
+	fmt.Println("  pstree                # все процессы")
+	fmt.Println("  pstree -p 1234        # поддерево процесса 1234 с номерами")
+	fmt.Println("  pstree -a www-data    # процессы пользователя с аргументами")
+}
+
+// printVersion выводит информацию о версии
+func printVersion() {
+	fmt.Println("pstree версия", ver)
+	fmt.Println("Разработано в рамках учебного проекта")
+	fmt.Println("Язык программирования: Golang")
+}
+
+// node - процесс или поток в дереве
+type node struct {
+	pid      int
+	name     string // имя процесса; у потока - {имя}
+	args     string // аргументы командной строки без имени программы
+	thread   bool
+	children []*node
+}
+
+// label возвращает подпись узла: "bash(42)" или с -a "bash,42 -l"
+func (n *node) label(config *Config) string {
+	if !config.Args {
+		if config.ShowPIDs {
+			return n.name + "(" + strconv.Itoa(n.pid) + ")"
+		}
+		return n.name
+	}
+	text := n.name
+	if config.ShowPIDs {
+		text += "," + strconv.Itoa(n.pid)
+	}
+	if n.args != "" {
+		text += " " + n.args
+	}
+	return text
+}
+
+// buildTree строит узлы для процесса и его потомков
+func buildTree(p *proc.Process, children map[int][]*proc.Process, config *Config) *node {
+	n := &node{pid: p.PID, name: p.Name}
+	if len(p.Cmdline) > 1 {
+		n.args = proc.Printable(strings.Join(p.Cmdline[1:], " "))
+	}
+	for _, child := range children[p.PID] {
+		n.children = append(n.children, buildTree(child, children, config))
+	}
+	if !config.HideThreads && p.Threads > 1 {
+		for _, tid := range proc.ThreadIDs(p.PID) {
+			n.children = append(n.children, &node{pid: tid, name: "{" + p.Name + "}", thread: true})
+		}
+	}
+
+	sort.SliceStable(n.children, func(i, j int) bool {
+		a, b := n.children[i], n.children[j]
+		if !config.NumericSort && a.name != b.name {
+			return a.name < b.name
+		}
+		return a.pid < b.pid
+	})
+	return n
+}
+
+// group - одинаковые соседние поддеревья, выводимые как N*[...]
+type group struct {
+	first *node
+	count int
+}
+
+// groupChildren объединяет одинаковых детей. Дети отсортированы по имени,
+// поэтому одинаковые поддеревья стоят рядом. С -a, как в psmisc,
+// сворачиваются только потоки: у процессов важны их аргументы.
+func groupChildren(n *node, config *Config) []group {
+	var groups []group
+	for _, child := range n.children {
+		last := len(groups) - 1
+		compact := !config.NoCompact && (!config.Args || child.thread)
+		if compact && last >= 0 && sameTree(groups[last].first, child, config) {
+			groups[last].count++
+			continue
+		}
+		groups = append(groups, group{first: child, count: 1})
+	}
+	return groups
+}
+
+// sameTree сравнивает поддеревья без учета номеров процессов
+func sameTree(a, b *node, config *Config) bool {
+	if a.label(config) != b.label(config) || len(a.children) != len(b.children) {
+		return false
+	}
+	for i := range a.children {
+		if !sameTree(a.children[i], b.children[i], config) {
+			return false
+		}
+	}
+	return true
+}
+
+// wrapGroup оформляет свернутое поддерево: N*[первая строка ... последняя]
+func wrapGroup(block []string, count int) []string {
+	if count == 1 {
+		return block
+	}
+	head := strconv.Itoa(count) + "*["
+	pad := strings.Repeat(" ", len(head))
+	out := make([]string, len(block))
+	for i, line := range block {
+		if i == 0 {
+			out[i] = head + line
+		} else {
+			out[i] = pad + line
+		}
+	}
+	out[len(out)-1] += "]"
+	return out
+}
+
+// renderWide рисует дерево в стиле pstree: дети справа от родителя
+//
+//	init-+-getty
+//	     |-2*[sshd---bash]
+//	     `-cron
+func renderWide(n *node, config *Config, l lines) []string {
+	label := n.label(config)
+	groups := groupChildren(n, config)
+	if len(groups) == 0 {
+		return []string{label}
+	}
+
+	indent := strings.Repeat(" ", display.String(label))
+	var out []string
+	for i, g := range groups {
+		block := wrapGroup(renderWide(g.first, config, l), g.count)
+		lastChild := i == len(groups)-1
+
+		var connector, cont string
+		switch {
+		case len(groups) == 1:
+			connector, cont = l.single, "   "
+		case i == 0:
+			connector, cont = l.first, l.bar
+		case lastChild:
+			connector, cont = l.last, "   "
+		default:
+			connector, cont = l.middle, l.bar
+		}
+
+		for j, line := range block {
+			switch {
+			case i == 0 && j == 0:
+				out = append(out, label+connector+line)
+			case j == 0:
+				out = append(out, indent+connector+line)
+			default:
+				out = append(out, indent+cont+line)
+			}
+		}
+	}
+	return out
+}
+
+// renderTall рисует дерево для -a: каждый процесс на своей строке
+//
+//	init
+//	  |-getty --noclear tty1
+//	  `-sshd -D
+//	      `-sshd: alice
+func renderTall(n *node, config *Config, l lines) []string {
+	out := []string{n.label(config)}
+	groups := groupChildren(n, config)
+	for i, g := range groups {
+		block := renderTall(g.first, config, l)
+		if g.count > 1 {
+			block[0] = strconv.Itoa(g.count) + "*[" + block[0] + "]"
+		}
+		connector, cont := "  "+strings.TrimPrefix(l.middle, " "), "  "+strings.TrimPrefix(l.bar, " ")
+		if i == len(groups)-1 {
+			connector, cont = "  "+strings.TrimPrefix(l.last, " "), "    "
+		}
+		for j, line := range block {
+			if j == 0 {
+				out = append(out, connector+line)
+			} else {
+				out = append(out, cont+line)
+			}
+		}
+	}
+	return out
+}
+
+// executePstree строит и выводит дерево
+func executePstree(config *Config) error {
+	procs, err := proc.List()
+	if err != nil {
+		return err
+	}
+	children := proc.Children(procs)
+
+	var roots []*proc.Process
+	if config.User != "" {
+		// Корни - процессы пользователя, родитель которых принадлежит другому
+		uid, err := proc.LookupUID(config.User)
+		if err != nil {
+			return err
+		}
+		owner := map[int]int{}
+		for _, p := range procs {
+			owner[p.PID] = p.UID
+		}
+		for _, p := range procs {
+			if parent, ok := owner[p.PPID]; p.UID == uid && (!ok || parent != uid) {
+				roots = append(roots, p)
+			}
+		}
+	} else {
+		for _, p := range procs {
+			if p.PID == config.RootPID {
+				roots = append(roots, p)
+			}
+		}
+		if len(roots) == 0 {
+			// Нет процесса 1 (например, в отдельном пространстве PID):
+			// выводим все деревья
+			if config.RootPID != 1 {
+				return fmt.Errorf("процесс %d не найден", config.RootPID)
+			}
+			roots = proc.Roots(procs)
+		}
+	}
+
+	l := asciiLines
+	if config.Unicode {
+		l = unicodeLines
+	}
+	out := bufio.NewWriter(os.Stdout)
+	defer out.Flush()
+	for _, root := range roots {
+		tree := buildTree(root, children, config)
+		var rendered []string
+		if config.Args {
+			rendered = renderTall(tree, config, l)
+		} else {
+			rendered = renderWide(tree, config, l)
+		}
+		for _, line := range rendered {
+			fmt.Fprintln(out, line)
+		}
+	}
+	return nil
+}
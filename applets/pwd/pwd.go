@@ -1,10 +1,12 @@
-package main
+package pwd
 
 import (
-    "flag"
     "fmt"
     "os"
     "path/filepath"
+
+    "github.com/mir-yks/LinuxCommandAnalog/internal/applet"
+    "github.com/mir-yks/LinuxCommandAnalog/internal/getopt"
 )
 
 // Структура для хранения аргументов команды
@@ -31,22 +33,19 @@ func getCurrentDir(options PwdOptions) (string, error) {
 // Функция для проверки и обработки флагов
 func parseFlags() PwdOptions {
     var options PwdOptions
-    
-    flag.BoolVar(&options.Help, "h", false, "показать справку об использовании")
-    flag.BoolVar(&options.Help, "help", false, "показать справку об использовании")
-    flag.BoolVar(&options.Logical, "L", false, "использовать логический путь (разрешить символьные ссылки)")
-    flag.BoolVar(&options.Physical, "P", false, "использовать физический путь (без символьных ссылок)")
-    
-    flag.Parse()
-    
-    if flag.NArg() > 0 {
-        fmt.Fprintf(os.Stderr, "pwd: лишний операнд '%s'\n", flag.Arg(0))
-        fmt.Fprintf(os.Stderr, "Используйте 'pwd -h' для получения справки\n")
-        os.Exit(1)
+
+    opts := getopt.New("pwd")
+    opts.Bool(&options.Help, 'h', "help")
+    opts.Bool(&options.Logical, 'L', "logical")
+    opts.Bool(&options.Physical, 'P', "physical")
+    args := opts.Parse(os.Args[1:])
+
+    if len(args) > 0 {
+        opts.Failf("лишний операнд '%s'", args[0])
     }
-    
+
     options.useLogical = options.Logical && !options.Physical
-    
+
     return options
 }
 
@@ -68,7 +67,12 @@ func showHelp() {
     fmt.Println("  pwd -P        # Использовать физический путь")
 }
 
-func main() {
+func init() {
+    applet.Register("pwd", Main)
+}
+
+// Main запускает утилиту pwd
+func Main() {
     options := parseFlags()
     
     if options.Help {
@@ -1,4 +1,4 @@
-package main
+package pwgen
 
 import (
 	"crypto/rand"
@@ -6,6 +6,9 @@ import (
 	"math/big"
 	"os"
 	"strconv"
+
+	"github.com/mir-yks/LinuxCommandAnalog/internal/applet"
+	"github.com/mir-yks/LinuxCommandAnalog/internal/getopt"
 )
 
 const (
@@ -25,7 +28,12 @@ type Config struct {
 	NumColumns     int  
 }
 
-func main() {
+func init() {
+	applet.Register("pwgen", Main)
+}
+
+// Main запускает утилиту pwgen
+func Main() {
 	defer func() {
 		if r := recover(); r != nil {
 			fmt.Fprintf(os.Stderr, "pwgen: критическая ошибка: %v\n", r)
@@ -62,57 +70,26 @@ func main() {
 // parseArgs разбирает аргументы командной строки
 func parseArgs() *Config {
 	config := &Config{Length: 8, Count: 160, NumColumns: 8}
-	i := 1
-
-	for i < len(os.Args) {
-		arg := os.Args[i]
-
-		switch arg {
-		case "-h":
-			config.Help = true
-			i++
-			return config
-		case "-n":
-			config.IncludeNumbers = true
-			i++
-			continue
-		case "-s":
-			config.IncludeSymbols = true
-			i++
-			continue
-		case "-y":
-			config.AddSpecial = true
-			i++
-			continue
-		default:
-			if len(arg) > 1 && arg[0] == '-' {
-				for _, ch := range arg[1:] {
-					switch ch {
-					case 'h':
-						config.Help = true
-						return config
-					case 'n':
-						config.IncludeNumbers = true
-					case 's':
-						config.IncludeSymbols = true
-					case 'y':
-						config.AddSpecial = true
-					default:
-						panic(fmt.Sprintf("pwgen: неверная опция — '%s'", arg))
-					}
-				}
-				i++
-				continue
-			}
-
-			if length, err := strconv.Atoi(arg); err == nil && length > 0 {
-				config.Length = length
-				i++
-				continue
-			}
-
-			panic(fmt.Sprintf("pwgen: неверный аргумент '%s'", arg))
+
+	opts := getopt.New("pwgen")
+	opts.Bool(&config.Help, 'h', "help")
+	opts.Bool(&config.IncludeNumbers, 'n', "numerals")
+	opts.Bool(&config.IncludeSymbols, 's', "")
+	opts.Bool(&config.AddSpecial, 'y', "symbols")
+	args := opts.Parse(os.Args[1:])
+
+	if len(args) > 2 {
+		opts.Failf("лишний аргумент '%s'", args[2])
+	}
+	for i, target := range []*int{&config.Length, &config.Count} {
+		if i >= len(args) {
+			break
+		}
+		n, err := strconv.Atoi(args[i])
+		if err != nil || n <= 0 {
+			opts.Failf("неверный аргумент '%s'", args[i])
 		}
+		*target = n
 	}
 
 	return config
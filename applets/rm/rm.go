@@ -1,8 +1,11 @@
-package main
+package rm
 
 import (
 	"fmt"
 	"os"
+
+	"github.com/mir-yks/LinuxCommandAnalog/internal/applet"
+	"github.com/mir-yks/LinuxCommandAnalog/internal/getopt"
 )
 
 type Config struct {
@@ -43,44 +46,26 @@ func printVersion() {
 	fmt.Println("Язык программирования: Golang")
 }
 
-// parseArgs разбирает аргументы командной строки в стиле ls
+// parseArgs разбирает аргументы командной строки
 func parseArgs() *Config {
 	config := &Config{}
-	i := 1
-
-	for i < len(os.Args) {
-		arg := os.Args[i]
-
-		switch arg {
-		case "-h", "--help":
-			config.Help = true
-			i++
-			continue
-		case "-v", "--version":
-			if arg == "-v" {
-				config.Verbose = true
-			} else {
-				config.Version = true
-			}
-			i++
-			continue
-		case "-f":
-			config.Force = true
-			i++
-			continue
-		case "-R", "-r":
-			config.Recursive = true
-			i++
-			continue
-		default:
-			if len(arg) > 1 && arg[0] == '-' {
-				panic(fmt.Sprintf("rm: неверный ключ '%s'. Используйте --help для справки", arg))
-			}
-			config.Paths = append(config.Paths, arg)
-			i++
-		}
-	}
 
+	opts := getopt.New("rm")
+	opts.Bool(&config.Help, 'h', "help")
+	opts.Bool(&config.Version, 0, "version")
+	opts.Bool(&config.Verbose, 'v', "verbose")
+	opts.Bool(&config.Force, 'f', "force")
+	opts.Bool(&config.Recursive, 'r', "recursive")
+	opts.Bool(&config.Recursive, 'R', "")
+	config.Paths = opts.Parse(os.Args[1:])
+
+	// Как в GNU rm, с -f отсутствие операндов не ошибка
+	if config.Help || config.Version || config.Force {
+		return config
+	}
+	if len(config.Paths) == 0 {
+		opts.Failf("пропущен операнд")
+	}
 	return config
 }
 
@@ -108,26 +93,28 @@ func removeFile(path string, force, recursive, verbose bool) error {
 	return err
 }
 
-// executeRm выполняет удаление файлов/директорий
-func executeRm(config *Config) {
-	if len(config.Paths) == 0 {
-		panic("rm: пропущен операнд")
-	}
-
+// executeRm выполняет удаление файлов/директорий. Ошибка с одним путем
+// не мешает удалить остальные; false - если без -f что-то не удалилось.
+func executeRm(config *Config) bool {
+	ok := true
 	for _, path := range config.Paths {
 		err := removeFile(path, config.Force, config.Recursive, config.Verbose)
-		
 		if err != nil {
-			if config.Force {
-				fmt.Fprintf(os.Stderr, "rm: %v\n", err)
-			} else {
-				panic(err)
+			fmt.Fprintf(os.Stderr, "rm: %v\n", err)
+			if !config.Force {
+				ok = false
 			}
 		}
 	}
+	return ok
 }
 
-func main() {
+func init() {
+	applet.Register("rm", Main)
+}
+
+// Main запускает утилиту rm
+func Main() {
 	defer func() {
 		if r := recover(); r != nil {
 			fmt.Fprintf(os.Stderr, "rm: %v\n", r)
@@ -147,6 +134,8 @@ func main() {
 		return
 	}
 
-	executeRm(config)
+	if !executeRm(config) {
+		os.Exit(1)
+	}
 }
 
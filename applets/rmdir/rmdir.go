@@ -1,9 +1,12 @@
-package main
+package rmdir
 
 import (
 	"fmt"
 	"os"
 	"path/filepath"
+
+	"github.com/mir-yks/LinuxCommandAnalog/internal/applet"
+	"github.com/mir-yks/LinuxCommandAnalog/internal/getopt"
 )
 
 type Config struct {
@@ -16,7 +19,12 @@ type Config struct {
 
 const ver = "1.0.0"
 
-func main() {
+func init() {
+	applet.Register("rmdir", Main)
+}
+
+// Main запускает утилиту rmdir
+func Main() {
 	defer func() {
 		if r := recover(); r != nil {
 			fmt.Fprintf(os.Stderr, "Ошибка: %v\n", r)
@@ -36,62 +44,26 @@ func main() {
 		return
 	}
 
-	if len(config.Dirs) == 0 {
-		fmt.Fprintln(os.Stderr, "rmdir: пропущена директория")
-		fmt.Fprintln(os.Stderr, "По команде «rmdir -h» можно получить дополнительную информацию.")
-		os.Exit(1)
-	}
-
 	executeRmdir(config)
 }
 
-// parseArgs разбирает аргументы командной строки вручную
+// parseArgs разбирает аргументы командной строки
 func parseArgs() *Config {
 	config := &Config{}
-	i := 1
-
-	for i < len(os.Args) {
-		arg := os.Args[i]
-
-		switch arg {
-		case "-h":
-			config.Help = true
-			i++
-			continue
-		case "-p":
-			config.Parents = true
-			i++
-			continue
-		case "-v":
-			config.Verbose = true
-			i++
-			continue
-		case "--version":
-			config.Version = true
-			i++
-			continue
-		default:
-			if len(arg) > 1 && arg[0] == '-' {
-				for _, ch := range arg[1:] {
-					switch ch {
-					case 'h':
-						config.Help = true
-					case 'p':
-						config.Parents = true
-					case 'v':
-						config.Verbose = true
-					default:
-						panic(fmt.Sprintf("rmdir: неверный ключ — '%s'", arg))
-					}
-				}
-				i++
-				continue
-			}
-			config.Dirs = append(config.Dirs, arg)
-			i++
-		}
-	}
 
+	opts := getopt.New("rmdir")
+	opts.Bool(&config.Help, 'h', "help")
+	opts.Bool(&config.Parents, 'p', "parents")
+	opts.Bool(&config.Verbose, 'v', "verbose")
+	opts.Bool(&config.Version, 0, "version")
+	config.Dirs = opts.Parse(os.Args[1:])
+
+	if config.Help || config.Version {
+		return config
+	}
+	if len(config.Dirs) == 0 {
+		opts.Failf("пропущен операнд")
+	}
 	return config
 }
 
@@ -0,0 +1,193 @@
+package tail
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"syscall"
+	"time"
+
+	"github.com/mir-yks/LinuxCommandAnalog/internal/input"
+)
+
+// watcher будит цикл слежения, когда в файлах что-то могло измениться.
+// На Linux это inotify, в остальных случаях - простой опрос по таймеру.
+type watcher interface {
+	// Watch начинает следить за файлом; byName дополнительно следит
+	// за его директорией, чтобы заметить появление нового файла с тем же именем
+	Watch(path string, byName bool)
+	// Wait ждет события, но не дольше timeout
+	Wait(timeout time.Duration)
+}
+
+// pollWatcher ничего не отслеживает и просто выжидает интервал опроса
+type pollWatcher struct{}
+
+func (pollWatcher) Watch(string, bool) {}
+
+func (pollWatcher) Wait(timeout time.Duration) {
+	time.Sleep(timeout)
+}
+
+// output выводит данные файлов и заголовки "==> имя <==" при смене файла
+type output struct {
+	headers bool
+	current string
+	started bool
+}
+
+// header выводит заголовок, если предыдущий вывод относился к другому файлу
+func (o *output) header(name string) {
+	if !o.headers || (o.started && o.current == name) {
+		return
+	}
+	if o.started {
+		fmt.Println()
+	}
+	if name == input.Stdin {
+		name = "стандартный ввод"
+	}
+	fmt.Printf("==> %s <==\n", name)
+	o.current = name
+	o.started = true
+}
+
+// fileWriter пишет данные одного файла, выводя заголовок перед первой порцией
+type fileWriter struct {
+	out  *output
+	name string
+}
+
+func (w fileWriter) Write(p []byte) (int, error) {
+	w.out.header(w.name)
+	return os.Stdout.Write(p)
+}
+
+// tailFile - файл, за которым следит tail
+type tailFile struct {
+	name   string
+	file   *input.File // nil, пока файл недоступен
+	info   os.FileInfo // для распознавания замены файла при ротации
+	offset int64       // сколько байт уже выведено; уменьшение размера значит усечение
+	gone   bool        // о недоступности уже сообщено
+}
+
+// newTailFile начинает слежение за открытым файлом с текущей позиции
+func newTailFile(name string, file *input.File) (*tailFile, error) {
+	info, err := file.Stat()
+	if err != nil {
+		return nil, err
+	}
+	offset, err := file.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return nil, err
+	}
+	return &tailFile{name: name, file: file, info: info, offset: offset}, nil
+}
+
+// follow выводит новые данные файлов, пока tail не прервут или не
+// завершится процесс, заданный --pid
+func follow(files []*tailFile, out *output, config *Config) {
+	w := newWatcher()
+	for _, tf := range files {
+		if tf.file != nil {
+			w.Watch(tf.name, config.Follow == followName)
+		} else {
+			w.Watch(tf.name, true)
+		}
+	}
+
+	for {
+		// Процесс проверяется до чтения, чтобы после его смерти
+		// вывести все, что он успел записать
+		alive := config.Pid == 0 || processAlive(config.Pid)
+
+		for _, tf := range files {
+			if config.Follow == followName {
+				reopenIfReplaced(tf, out, w)
+			}
+			readNew(tf, out)
+		}
+
+		if !alive {
+			return
+		}
+		w.Wait(config.Sleep)
+	}
+}
+
+// readNew выводит данные, дописанные в файл с прошлой проверки
+func readNew(tf *tailFile, out *output) {
+	if tf.file == nil {
+		return
+	}
+
+	info, err := tf.file.Stat()
+	if err != nil {
+		return
+	}
+	if info.Size() < tf.offset {
+		fmt.Fprintf(os.Stderr, "tail: %s: файл усечен\n", tf.name)
+		if _, err := tf.file.Seek(0, io.SeekStart); err != nil {
+			return
+		}
+		tf.offset = 0
+	}
+	if info.Size() == tf.offset {
+		return
+	}
+
+	n, err := io.Copy(fileWriter{out: out, name: tf.name}, tf.file)
+	tf.offset += n
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "tail: %v\n", input.ReadError(tf.name, err))
+	}
+}
+
+// reopenIfReplaced переоткрывает файл, если под его именем теперь другой
+// файл (ротация журнала), и сообщает о пропаже и появлении файла
+func reopenIfReplaced(tf *tailFile, out *output, w watcher) {
+	info, err := os.Stat(tf.name)
+	if err != nil {
+		err = input.Describe(err)
+		if !tf.gone {
+			fmt.Fprintf(os.Stderr, "tail: '%s' стал недоступен: %v\n", tf.name, err)
+			tf.gone = true
+		}
+		return
+	}
+	if tf.file != nil && os.SameFile(info, tf.info) {
+		return
+	}
+
+	file, err := input.Open(tf.name)
+	if err != nil {
+		return
+	}
+	newInfo, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return
+	}
+
+	if tf.file != nil {
+		// Дочитываем хвост старого файла, записанный до ротации
+		readNew(tf, out)
+		tf.file.Close()
+		fmt.Fprintf(os.Stderr, "tail: '%s' был заменен; следую за новым файлом\n", tf.name)
+	} else {
+		fmt.Fprintf(os.Stderr, "tail: '%s' появился; следую за новым файлом\n", tf.name)
+	}
+
+	tf.file = file
+	tf.info = newInfo
+	tf.offset = 0
+	tf.gone = false
+	w.Watch(tf.name, true)
+}
+
+// processAlive проверяет, что процесс существует
+func processAlive(pid int) bool {
+	err := syscall.Kill(pid, 0)
+	return err == nil || err == syscall.EPERM
+}
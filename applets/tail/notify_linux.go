@@ -0,0 +1,64 @@
+package tail
+
+import (
+	"path/filepath"
+	"syscall"
+	"time"
+)
+
+// inotifyWatcher будит цикл слежения по событиям inotify
+type inotifyWatcher struct {
+	fd     int
+	events chan struct{}
+}
+
+// newWatcher возвращает inotify-наблюдатель или, если inotify недоступен
+// (исчерпан лимит, старое ядро), опрос по таймеру
+func newWatcher() watcher {
+	fd, err := syscall.InotifyInit1(syscall.IN_CLOEXEC)
+	if err != nil {
+		return pollWatcher{}
+	}
+
+	w := &inotifyWatcher{fd: fd, events: make(chan struct{}, 1)}
+	go w.read()
+	return w
+}
+
+// read вычитывает события; само содержимое событий не важно, цикл
+// слежения после пробуждения проверяет все файлы
+func (w *inotifyWatcher) read() {
+	buf := make([]byte, 4096)
+	for {
+		n, err := syscall.Read(w.fd, buf)
+		if err == syscall.EINTR {
+			continue
+		}
+		if err != nil || n <= 0 {
+			// Дальше Wait работает как опрос по таймеру
+			return
+		}
+		select {
+		case w.events <- struct{}{}:
+		default:
+		}
+	}
+}
+
+func (w *inotifyWatcher) Watch(path string, byName bool) {
+	const fileEvents = syscall.IN_MODIFY | syscall.IN_ATTRIB | syscall.IN_DELETE_SELF | syscall.IN_MOVE_SELF
+	const dirEvents = syscall.IN_CREATE | syscall.IN_MOVED_TO | syscall.IN_DELETE | syscall.IN_MOVED_FROM
+
+	// Ошибки не фатальны: файл все равно проверяется раз в интервал опроса
+	syscall.InotifyAddWatch(w.fd, path, fileEvents)
+	if byName {
+		syscall.InotifyAddWatch(w.fd, filepath.Dir(path), dirEvents)
+	}
+}
+
+func (w *inotifyWatcher) Wait(timeout time.Duration) {
+	select {
+	case <-w.events:
+	case <-time.After(timeout):
+	}
+}
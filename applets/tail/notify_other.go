@@ -0,0 +1,8 @@
+//go:build !linux
+
+package tail
+
+// newWatcher возвращает опрос по таймеру: inotify есть только в Linux
+func newWatcher() watcher {
+	return pollWatcher{}
+}
@@ -0,0 +1,448 @@
+package tail
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mir-yks/LinuxCommandAnalog/internal/applet"
+	"github.com/mir-yks/LinuxCommandAnalog/internal/getopt"
+	"github.com/mir-yks/LinuxCommandAnalog/internal/input"
+)
+
+// Режимы слежения за файлом
+const (
+	followNone       = ""
+	followDescriptor = "descriptor"
+	followName       = "name"
+)
+
+type Config struct {
+	Help      bool
+	Version   bool
+	Count     int64         // сколько строк или байт выводить
+	Bytes     bool          // считать байты (-c), а не строки (-n)
+	FromStart bool          // +K: выводить, начиная с K-й строки или байта
+	Follow    string        // followNone, followDescriptor или followName
+	Retry     bool          // ждать появления недоступного файла
+	Pid       int           // завершиться после смерти процесса PID
+	Sleep     time.Duration // интервал опроса файлов
+	Quiet     bool
+	Verbose   bool
+	Filenames []string
+}
+
+const ver = "1.0.0"
+
+func init() {
+	applet.Register("tail", Main)
+}
+
+// Main запускает утилиту tail
+func Main() {
+	defer func() {
+		if r := recover(); r != nil {
+			fmt.Fprintf(os.Stderr, "Ошибка: %v\n", r)
+			os.Exit(1)
+		}
+	}()
+
+	config := parseArgs()
+
+	if config.Help {
+		printHelp()
+		return
+	}
+
+	if config.Version {
+		printVersion()
+		return
+	}
+
+	if !executeTail(config) {
+		os.Exit(1)
+	}
+}
+
+// parseArgs разбирает аргументы командной строки
+func parseArgs() *Config {
+	config := &Config{Count: 10, Sleep: time.Second}
+
+	opts := getopt.New("tail")
+	opts.Bool(&config.Help, 'h', "help")
+	opts.Bool(&config.Version, 'v', "version")
+	opts.Func('c', "bytes", getopt.RequiredArgument, func(value string) error {
+		config.Bytes = true
+		return parseCount(value, config)
+	})
+	opts.Func('n', "lines", getopt.RequiredArgument, func(value string) error {
+		config.Bytes = false
+		return parseCount(value, config)
+	})
+	opts.Func('f', "follow", getopt.OptionalArgument, func(value string) error {
+		switch value {
+		case "", followDescriptor:
+			config.Follow = followDescriptor
+		case followName:
+			config.Follow = followName
+		default:
+			return fmt.Errorf("неверный режим '%s'. Используйте 'descriptor' или 'name'", value)
+		}
+		return nil
+	})
+	opts.Func('F', "", getopt.NoArgument, func(string) error {
+		config.Follow = followName
+		config.Retry = true
+		return nil
+	})
+	opts.Bool(&config.Retry, 0, "retry")
+	opts.Int(&config.Pid, 0, "pid")
+	opts.Func('s', "sleep-interval", getopt.RequiredArgument, func(value string) error {
+		seconds, err := strconv.ParseFloat(value, 64)
+		if err != nil || seconds < 0 {
+			return fmt.Errorf("неверный интервал '%s'", value)
+		}
+		config.Sleep = time.Duration(seconds * float64(time.Second))
+		return nil
+	})
+	opts.Bool(&config.Quiet, 'q', "quiet")
+	opts.Bool(&config.Quiet, 0, "silent")
+	opts.Bool(&config.Verbose, 0, "verbose")
+	operands := opts.Parse(os.Args[1:])
+
+	if config.Pid < 0 {
+		opts.Failf("неверный PID '%d'", config.Pid)
+	}
+	config.Filenames = input.Operands(operands)
+
+	return config
+}
+
+// parseCount разбирает значение -n/-c: K или -K - последние K,
+// +K - начиная с K-го
+func parseCount(value string, config *Config) error {
+	config.FromStart = strings.HasPrefix(value, "+")
+	count, err := strconv.ParseInt(strings.TrimLeft(value, "+-"), 10, 64)
+	if err != nil || count < 0 {
+		return fmt.Errorf("неверное количество '%s'", value)
+	}
+	config.Count = count
+	return nil
+}
+
+// printHelp выводит справку
+func printHelp() {
+	fmt.Println("tail - выводит конец каждого заданного файла")
+	fmt.Println()
+	fmt.Println("Использование: tail [ОПЦИЯ]... [ФАЙЛ]...")
+	fmt.Println()
+	fmt.Println("Опции:")
+	fmt.Println("  -c N            выводить последние N байт; -c +N - начиная с N-го байта")
+	fmt.Println("  -n N            выводить последние N строк; -n +N - начиная с N-й строки")
+	fmt.Println("  -f, --follow[=descriptor|name]")
+	fmt.Println("                  выводить новые данные по мере их появления;")
+	fmt.Println("                  name - следить за именем и переоткрывать файл после ротации")
+	fmt.Println("  -F              то же, что --follow=name --retry")
+	fmt.Println("  --retry         ждать появления недоступного файла")
+	fmt.Println("  --pid=PID       вместе с -f: завершиться после завершения процесса PID")
+	fmt.Println("  -s N            вместе с -f: проверять файлы раз в N секунд (по умолчанию 1)")
+	fmt.Println("  -q, --quiet     никогда не выводить заголовки с именами файлов")
+	fmt.Println("  --verbose       всегда выводить заголовки с именами файлов")
+	fmt.Println("  -h              показать эту справку")
+	fmt.Println("  -v, --version   показать информацию о версии")
+	fmt.Println()
+	fmt.Println("По умолчанию N=10 строк. Если ФАЙЛ не задан или задан как -,")
+	fmt.Println("читается стандартный ввод.")
+	fmt.Println()
+	fmt.Println("Примеры:")
+	fmt.Println("  tail file.txt              # Последние 10 строк")
+	fmt.Println("  tail -n 5 file.txt         # Последние 5 строк")
+	fmt.Println("  tail -c 100 file.txt       # Последние 100 байт")
+	fmt.Println("  tail -n +2 data.csv        # Все строки, кроме первой")
+	fmt.Println("  tail -F /var/log/app.log   # Следить за журналом с учетом ротации")
+	fmt.Println("  tail -f --pid=1234 a.log   # Следить, пока жив процесс 1234")
+}
+
+// printVersion выводит информацию о версии
+func printVersion() {
+	fmt.Println("tail версия", ver)
+	fmt.Println("Разработано в рамках учебного проекта")
+	fmt.Println("Язык программирования: Golang")
+}
+
+// executeTail выводит конец каждого файла и, если задан -f, следит за ними.
+// Возвращает false, если хотя бы один файл не удалось обработать.
+func executeTail(config *Config) bool {
+	// Как в GNU tail, при нулевом счете без -f файлы даже не открываются:
+	// выводить нечего, в том числе заголовки
+	empty := config.Count == 0 && !config.FromStart
+	if empty && config.Follow == followNone {
+		return true
+	}
+
+	out := &output{
+		headers: config.Verbose || (len(config.Filenames) > 1 && !config.Quiet),
+	}
+	ok := true
+	var followed []*tailFile
+
+	for _, name := range config.Filenames {
+		file, err := input.Open(name)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "tail: %v\n", input.OpenError(name, err))
+			ok = false
+			if config.Follow == followName && config.Retry {
+				followed = append(followed, &tailFile{name: name, gone: true})
+			}
+			continue
+		}
+
+		// С -f заголовок появится перед первыми новыми данными
+		if !empty {
+			out.header(name)
+		}
+		if err := printTail(file, config); err != nil {
+			fmt.Fprintf(os.Stderr, "tail: %v\n", input.ReadError(name, err))
+			ok = false
+		}
+
+		// Канал на стандартном вводе не может вырасти после EOF
+		if config.Follow == followNone || !file.Seekable() {
+			file.Close()
+			continue
+		}
+		tf, err := newTailFile(name, file)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "tail: %v\n", input.ReadError(name, err))
+			file.Close()
+			ok = false
+			continue
+		}
+		followed = append(followed, tf)
+	}
+
+	if len(followed) > 0 {
+		follow(followed, out, config)
+	}
+	return ok
+}
+
+// blockSize - размер блока, которыми файл читается с конца
+const blockSize = 64 * 1024
+
+// printTail выводит последние строки или байты уже открытого файла
+func printTail(file *input.File, config *Config) error {
+	switch {
+	case config.FromStart && config.Bytes:
+		return copyFromByte(file, config.Count)
+	case config.FromStart:
+		return copyFromLine(file, config.Count)
+	case config.Bytes:
+		return readLastBytes(file, config.Count)
+	default:
+		return readLastLines(file, config.Count)
+	}
+}
+
+// readLastLines выводит последние N строк. Файл читается блоками с конца,
+// пока не найдется N переводов строки, поэтому память и время зависят
+// только от объема вывода, а не от размера файла.
+func readLastLines(file *input.File, lineCount int64) error {
+	if !file.Seekable() {
+		return copyLastLines(file, lineCount)
+	}
+
+	size, err := file.Seek(0, io.SeekEnd)
+	if err != nil {
+		return err
+	}
+	if size == 0 || lineCount == 0 {
+		return nil
+	}
+
+	start, err := findLinesStart(file, size, lineCount)
+	if err != nil {
+		return err
+	}
+
+	if _, err := file.Seek(start, io.SeekStart); err != nil {
+		return err
+	}
+	if _, err := io.Copy(os.Stdout, file); err != nil {
+		return err
+	}
+	return nil
+}
+
+// findLinesStart возвращает смещение начала последних lineCount строк
+// файла размером size
+func findLinesStart(file *input.File, size, lineCount int64) (int64, error) {
+	buffer := make([]byte, blockSize)
+	end := size
+	found := int64(0)
+
+	// Перевод строки в самом конце файла завершает последнюю строку,
+	// а не начинает новую
+	last := make([]byte, 1)
+	if _, err := file.ReadAt(last, size-1); err != nil {
+		return 0, err
+	}
+	if last[0] == '\n' {
+		end--
+	}
+
+	for end > 0 {
+		start := end - blockSize
+		if start < 0 {
+			start = 0
+		}
+		block := buffer[:end-start]
+		if _, err := file.ReadAt(block, start); err != nil && err != io.EOF {
+			return 0, err
+		}
+
+		for i := len(block) - 1; i >= 0; i-- {
+			if block[i] != '\n' {
+				continue
+			}
+			found++
+			if found == lineCount {
+				return start + int64(i) + 1, nil
+			}
+		}
+		end = start
+	}
+
+	return 0, nil
+}
+
+// copyLastLines выводит последние N строк потока, который нельзя перемотать:
+// в памяти хранятся только последние N строк
+func copyLastLines(r io.Reader, lineCount int64) error {
+	if lineCount == 0 {
+		_, err := io.Copy(io.Discard, r)
+		return err
+	}
+
+	reader := bufio.NewReader(r)
+	ring := make([][]byte, 0, min(lineCount, 1024))
+	next := 0
+
+	for {
+		line, err := reader.ReadBytes('\n')
+		if len(line) > 0 {
+			if int64(len(ring)) < lineCount {
+				ring = append(ring, line)
+			} else {
+				ring[next] = line
+				next = (next + 1) % len(ring)
+			}
+		}
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	for i := range ring {
+		os.Stdout.Write(ring[(next+i)%len(ring)])
+	}
+	return nil
+}
+
+// copyFromLine выводит файл, начиная со строки номер lineNum (с 1)
+func copyFromLine(file *input.File, lineNum int64) error {
+	reader := bufio.NewReader(file)
+
+	for line := int64(1); line < lineNum; {
+		_, err := reader.ReadSlice('\n')
+		switch err {
+		case nil:
+			line++
+		case bufio.ErrBufferFull:
+			// Строка длиннее буфера: продолжаем искать ее конец
+		case io.EOF:
+			return nil
+		default:
+			return err
+		}
+	}
+
+	if _, err := io.Copy(os.Stdout, reader); err != nil {
+		return err
+	}
+	return nil
+}
+
+// copyFromByte выводит файл, начиная с байта номер byteNum (с 1)
+func copyFromByte(file *input.File, byteNum int64) error {
+	skip := max(byteNum-1, 0)
+
+	if file.Seekable() {
+		if _, err := file.Seek(skip, io.SeekStart); err != nil {
+			return err
+		}
+	} else if _, err := io.CopyN(io.Discard, file, skip); err != nil {
+		if err == io.EOF {
+			return nil
+		}
+		return err
+	}
+
+	if _, err := io.Copy(os.Stdout, file); err != nil {
+		return err
+	}
+	return nil
+}
+
+// readLastBytes выводит последние N байт файла
+func readLastBytes(file *input.File, byteCount int64) error {
+	if !file.Seekable() {
+		return copyLastBytes(file, byteCount)
+	}
+
+	size, err := file.Seek(0, io.SeekEnd)
+	if err != nil {
+		return err
+	}
+
+	start := max(size-byteCount, 0)
+	if _, err := file.Seek(start, io.SeekStart); err != nil {
+		return err
+	}
+
+	if _, err := io.Copy(os.Stdout, file); err != nil {
+		return err
+	}
+	return nil
+}
+
+// copyLastBytes выводит последние N байт потока, который нельзя перемотать:
+// поток читается целиком, но в памяти хранится не больше 2*N байт
+func copyLastBytes(r io.Reader, byteCount int64) error {
+	var tail []byte
+	buffer := make([]byte, 32*1024)
+
+	for {
+		n, err := r.Read(buffer)
+		tail = append(tail, buffer[:n]...)
+		if int64(len(tail)) > byteCount {
+			tail = append(tail[:0], tail[int64(len(tail))-byteCount:]...)
+		}
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	os.Stdout.Write(tail)
+	return nil
+}
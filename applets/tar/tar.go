@@ -1,4 +1,4 @@
-package main
+package tar
 
 import (
 	"archive/tar"
@@ -7,6 +7,9 @@ import (
 	"io"
 	"os"
 	"path/filepath"
+
+	"github.com/mir-yks/LinuxCommandAnalog/internal/applet"
+	"github.com/mir-yks/LinuxCommandAnalog/internal/getopt"
 )
 
 type Config struct {
@@ -48,50 +51,26 @@ func printVersion() {
 // parseArgs разбирает аргументы командной строки
 func parseArgs() *Config {
 	config := &Config{Archive: "archive.tar.gz"}
-	i := 1
-
-	for i < len(os.Args) {
-		arg := os.Args[i]
-
-		switch arg {
-		case "-h", "--help":
-			config.Help = true
-			i++
-			return config 
-		case "-v", "--version":
-			config.Version = true
-			i++
-			return config 
-		case "-c":
-			config.Create = true
-			i++
-			continue
-		case "-x":
-			config.Extract = true
-			i++
-			continue
-		case "-f":
-			if i+1 >= len(os.Args) {
-				panic("tar: опция -f требует аргумент")
-			}
-			i++
-			config.Archive = os.Args[i]
-			i++
-			continue
-		default:
-			if len(arg) > 1 && arg[0] == '-' {
-				panic(fmt.Sprintf("tar: неверный ключ '%s'. Используйте --help", arg))
-			}
-			config.Files = append(config.Files, arg)
-			i++
-		}
-	}
 
+	opts := getopt.New("tar")
+	opts.Bool(&config.Help, 'h', "help")
+	opts.Bool(&config.Version, 'v', "version")
+	opts.Bool(&config.Create, 'c', "create")
+	opts.Bool(&config.Extract, 'x', "extract")
+	opts.String(&config.Archive, 'f', "file")
+	config.Files = opts.Parse(os.Args[1:])
+
+	if config.Help || config.Version {
+		return config
+	}
 	if config.Create && config.Extract {
-		panic("tar: не удается одновременно создавать и распаковывать")
+		opts.Failf("не удается одновременно создавать и распаковывать")
 	}
 	if !config.Create && !config.Extract {
-		panic("tar: не указано действие (-c или -x)")
+		opts.Failf("не указано действие (-c или -x)")
+	}
+	if config.Create && len(config.Files) == 0 {
+		opts.Failf("не указаны файлы для архивации")
 	}
 
 	return config
@@ -209,7 +188,12 @@ func extractFile(tr *tar.Reader, header *tar.Header) error {
 	return err
 }
 
-func main() {
+func init() {
+	applet.Register("tar", Main)
+}
+
+// Main запускает утилиту tar
+func Main() {
 	defer func() {
 		if r := recover(); r != nil {
 			fmt.Fprintf(os.Stderr, "tar: %v\n", r)
@@ -230,16 +214,15 @@ func main() {
 	}
 
 	if config.Create {
-		if len(config.Files) == 0 {
-			panic("tar: не указаны файлы для архивации")
-		}
 		if err := createTarGz(config.Archive, config.Files); err != nil {
-			panic(err)
+			fmt.Fprintf(os.Stderr, "tar: %v\n", err)
+			os.Exit(1)
 		}
 		fmt.Printf("Архив создан: %s\n", config.Archive)
 	} else {
 		if err := extractTarGz(config.Archive); err != nil {
-			panic(err)
+			fmt.Fprintf(os.Stderr, "tar: %v\n", err)
+			os.Exit(1)
 		}
 		fmt.Printf("Архив распакован: %s\n", config.Archive)
 	}
@@ -0,0 +1,197 @@
+package top
+
+import (
+	"cmp"
+	"fmt"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/mir-yks/LinuxCommandAnalog/internal/display"
+	"github.com/mir-yks/LinuxCommandAnalog/internal/proc"
+)
+
+// sortKeys - поля сортировки; по умолчанию большие значения идут первыми,
+// кроме PID
+var sortKeys = map[string]func(a, b *task) int{
+	"cpu":  func(a, b *task) int { return cmp.Compare(b.CPU, a.CPU) },
+	"mem":  func(a, b *task) int { return cmp.Compare(b.RSS, a.RSS) },
+	"pid":  func(a, b *task) int { return cmp.Compare(a.PID, b.PID) },
+	"time": func(a, b *task) int { return cmp.Compare(b.UTime+b.STime, a.UTime+a.STime) },
+}
+
+// sortNames переводит имена столбцов procps (-o %CPU) в поля сортировки
+var sortNames = map[string]string{
+	"%cpu":  "cpu",
+	"%mem":  "mem",
+	"res":   "mem",
+	"time+": "time",
+}
+
+// visibleTasks отбирает и сортирует процессы для вывода
+func visibleTasks(s *sample, config *Config) []*task {
+	var tasks []*task
+	for _, t := range s.Tasks {
+		if len(config.PIDs) > 0 && !slices.Contains(config.PIDs, t.PID) {
+			continue
+		}
+		if config.UID >= 0 && t.UID != config.UID {
+			continue
+		}
+		if config.HideIdle && t.CPU == 0 && t.State != 'R' {
+			continue
+		}
+		tasks = append(tasks, t)
+	}
+
+	compare := sortKeys[config.Sort]
+	slices.SortStableFunc(tasks, func(a, b *task) int {
+		c := compare(a, b)
+		if c == 0 {
+			c = cmp.Compare(a.PID, b.PID)
+		}
+		if config.Reverse {
+			c = -c
+		}
+		return c
+	})
+	return tasks
+}
+
+// header возвращает пять строк сводки, как в procps top
+func header(prev, cur *sample) []string {
+	states := cpuStates(prev.CPU, cur.CPU)
+	mem := cur.Mem
+	mib := func(name string) float64 { return float64(mem[name]) / (1024 * 1024) }
+
+	total := mib("MemTotal")
+	available := mib("MemAvailable")
+	cache := mib("Buffers") + mib("Cached") + mib("SReclaimable")
+	swapTotal, swapFree := mib("SwapTotal"), mib("SwapFree")
+
+	return []string{
+		fmt.Sprintf("top - %s up %s,  load average: %.2f, %.2f, %.2f",
+			cur.Time.Format("15:04:05"), formatUptime(cur.Sys.Uptime), cur.Load[0], cur.Load[1], cur.Load[2]),
+		fmt.Sprintf("Tasks: %3d total, %3d running, %3d sleeping, %3d stopped, %3d zombie",
+			len(cur.Tasks), cur.running, cur.sleep, cur.stopped, cur.zombie),
+		fmt.Sprintf("%%Cpu(s): %4.1f us, %4.1f sy, %4.1f ni, %4.1f id, %4.1f wa, %4.1f hi, %4.1f si, %4.1f st",
+			states[0], states[1], states[2], states[3], states[4], states[5], states[6], states[7]),
+		fmt.Sprintf("MiB Mem : %8.1f total, %8.1f free, %8.1f used, %8.1f buff/cache",
+			total, mib("MemFree"), total-available, cache),
+		fmt.Sprintf("MiB Swap: %8.1f total, %8.1f free, %8.1f used. %8.1f avail Mem",
+			swapTotal, swapFree, swapTotal-swapFree, available),
+	}
+}
+
+// pidWidth - ширина столбца PID: число цифр в наибольшем PID системы,
+// как в procps; 0 - еще не определена
+var pidWidth int
+
+// columnWidth возвращает ширину столбца PID, читая kernel.pid_max один раз
+func columnWidth() int {
+	if pidWidth == 0 {
+		pidWidth = 5
+		if data, err := os.ReadFile("/proc/sys/kernel/pid_max"); err == nil {
+			pidWidth = max(pidWidth, len(strings.TrimSpace(string(data))))
+		}
+	}
+	return pidWidth
+}
+
+// columnHeader возвращает заголовок таблицы процессов
+func columnHeader() string {
+	return fmt.Sprintf("%*s %-8s %3s %3s %7s %6s %6s %s %5s %5s %9s %s", columnWidth(),
+		"PID", "USER", "PR", "NI", "VIRT", "RES", "SHR", "S", "%CPU", "%MEM", "TIME+", "COMMAND")
+}
+
+// formatTask возвращает строку таблицы для процесса
+func formatTask(t *task, s *sample, config *Config) string {
+	priority := strconv.Itoa(t.Priority)
+	if t.Priority < -99 {
+		priority = "rt"
+	}
+	command := t.Name
+	if config.FullCommand {
+		command = t.Command()
+	}
+	return fmt.Sprintf("%*d %-8s %3s %3d %7s %6s %6s %c %5.1f %5.1f %9s %s",
+		columnWidth(), t.PID, truncateUser(proc.UserName(t.UID)), priority, t.Nice,
+		scaleKiB(t.VSize/1024, 7), scaleKiB(t.RSS/1024, 6), scaleKiB(t.Shr/1024, 6),
+		t.State, t.CPU, t.MemPercent(s.Sys), formatCPUTime(t.UTime+t.STime, s.Sys.HZ),
+		proc.Printable(command))
+}
+
+// formatUptime выводит время работы системы, как uptime: "3 days,  4:05" или "17 min"
+func formatUptime(seconds float64) string {
+	total := int64(seconds)
+	days, hours, minutes := total/86400, total/3600%24, total/60%60
+
+	s := ""
+	if days == 1 {
+		s = "1 day, "
+	} else if days > 1 {
+		s = fmt.Sprintf("%d days, ", days)
+	}
+	if hours > 0 {
+		return s + fmt.Sprintf("%2d:%02d", hours, minutes)
+	}
+	return s + fmt.Sprintf("%d min", minutes)
+}
+
+// formatCPUTime выводит процессорное время в виде М:СС.сс, как столбец TIME+
+func formatCPUTime(ticks, hz uint64) string {
+	hundredths := ticks * 100 / hz
+	s := fmt.Sprintf("%d:%02d.%02d", hundredths/6000, hundredths/100%60, hundredths%100)
+	if len(s) > 9 {
+		// Слишком много минут: без сотых
+		s = fmt.Sprintf("%d:%02d", hundredths/6000, hundredths/100%60)
+	}
+	return s
+}
+
+// scaleKiB выводит размер в KiB; если число не помещается в width,
+// оно переводится в m, g, t с наибольшей точностью, которая помещается
+func scaleKiB(kb uint64, width int) string {
+	s := strconv.FormatUint(kb, 10)
+	if len(s) <= width {
+		return s
+	}
+	value := float64(kb)
+	for _, unit := range "mgtp" {
+		value /= 1024
+		for precision := 3; precision >= 0; precision-- {
+			s = strconv.FormatFloat(value, 'f', precision, 64) + string(unit)
+			if len(s) <= width {
+				return s
+			}
+		}
+	}
+	return s
+}
+
+// cut обрезает строку до ширины в ячейках терминала
+func cut(s string, width int) string {
+	if display.String(s) <= width {
+		return s
+	}
+	var b strings.Builder
+	used := 0
+	for _, r := range s {
+		rw := display.RuneWidth(r)
+		if used+rw > width {
+			break
+		}
+		b.WriteRune(r)
+		used += rw
+	}
+	return b.String()
+}
+
+// truncateUser укорачивает длинное имя до ширины столбца с "+" в конце
+func truncateUser(name string) string {
+	if len(name) > 8 {
+		return name[:7] + "+"
+	}
+	return name
+}
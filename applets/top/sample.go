@@ -0,0 +1,169 @@
+package top
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mir-yks/LinuxCommandAnalog/internal/proc"
+)
+
+// cpuTimes - строка cpu из /proc/stat: такты по состояниям процессора
+type cpuTimes struct {
+	User, Nice, System, Idle, IOWait, IRQ, SoftIRQ, Steal uint64
+}
+
+// total возвращает сумму тактов по всем состояниям
+func (c cpuTimes) total() uint64 {
+	return c.User + c.Nice + c.System + c.Idle + c.IOWait + c.IRQ + c.SoftIRQ + c.Steal
+}
+
+// task - процесс в снимке вместе с загрузкой процессора между снимками
+type task struct {
+	*proc.Process
+	CPU float64 // % одного процессора, как в procps top
+	Shr uint64  // разделяемая память в байтах
+}
+
+// sample - один снимок системы
+type sample struct {
+	Time    time.Time
+	CPU     cpuTimes
+	Load    [3]float64
+	Mem     map[string]uint64
+	Sys     *proc.System
+	Tasks   []*task
+	running int
+	sleep   int
+	stopped int
+	zombie  int
+}
+
+// takeSample читает процессы и общую статистику. По предыдущему снимку
+// считается доля процессора каждого процесса за время между снимками.
+func takeSample(prev *sample) (*sample, error) {
+	s := &sample{Time: time.Now()}
+
+	var err error
+	if s.CPU, err = readCPUTimes(); err != nil {
+		return nil, err
+	}
+	if s.Load, err = readLoadAvg(); err != nil {
+		return nil, err
+	}
+	if s.Mem, err = proc.MemInfo(); err != nil {
+		return nil, err
+	}
+	if s.Sys, err = proc.ReadSystem(); err != nil {
+		return nil, err
+	}
+	procs, err := proc.List()
+	if err != nil {
+		return nil, err
+	}
+
+	// Такты процессов в прошлом снимке; процесс с тем же PID, но другим
+	// моментом запуска - уже другой процесс
+	before := map[int]*proc.Process{}
+	if prev != nil {
+		for _, t := range prev.Tasks {
+			before[t.PID] = t.Process
+		}
+	}
+	interval := 0.0
+	if prev != nil {
+		interval = s.Time.Sub(prev.Time).Seconds()
+	}
+
+	for _, p := range procs {
+		t := &task{Process: p, Shr: readShared(p.PID)}
+		if old, ok := before[p.PID]; ok && old.Start == p.Start && interval > 0 {
+			used := float64(p.UTime+p.STime) - float64(old.UTime+old.STime)
+			t.CPU = max(used, 0) / float64(s.Sys.HZ) / interval * 100
+		}
+		s.Tasks = append(s.Tasks, t)
+
+		switch p.State {
+		case 'R':
+			s.running++
+		case 'T', 't':
+			s.stopped++
+		case 'Z':
+			s.zombie++
+		default:
+			s.sleep++
+		}
+	}
+	return s, nil
+}
+
+// readCPUTimes читает суммарную строку cpu из /proc/stat
+func readCPUTimes() (cpuTimes, error) {
+	data, err := os.ReadFile("/proc/stat")
+	if err != nil {
+		return cpuTimes{}, fmt.Errorf("не удалось прочитать /proc/stat: %v", err)
+	}
+	line, _, _ := strings.Cut(string(data), "\n")
+	fields := strings.Fields(line)
+	if len(fields) < 5 || fields[0] != "cpu" {
+		return cpuTimes{}, fmt.Errorf("неверный формат /proc/stat")
+	}
+	values := make([]uint64, 8)
+	for i := range values {
+		if i+1 < len(fields) {
+			values[i], _ = strconv.ParseUint(fields[i+1], 10, 64)
+		}
+	}
+	return cpuTimes{values[0], values[1], values[2], values[3], values[4], values[5], values[6], values[7]}, nil
+}
+
+// readLoadAvg читает среднюю загрузку за 1, 5 и 15 минут
+func readLoadAvg() ([3]float64, error) {
+	var load [3]float64
+	data, err := os.ReadFile("/proc/loadavg")
+	if err != nil {
+		return load, fmt.Errorf("не удалось прочитать /proc/loadavg: %v", err)
+	}
+	fields := strings.Fields(string(data))
+	for i := 0; i < 3 && i < len(fields); i++ {
+		load[i], _ = strconv.ParseFloat(fields[i], 64)
+	}
+	return load, nil
+}
+
+// readShared возвращает разделяемую память процесса из /proc/PID/statm
+func readShared(pid int) uint64 {
+	data, err := os.ReadFile("/proc/" + strconv.Itoa(pid) + "/statm")
+	if err != nil {
+		return 0
+	}
+	fields := strings.Fields(string(data))
+	if len(fields) < 3 {
+		return 0
+	}
+	pages, _ := strconv.ParseUint(fields[2], 10, 64)
+	return pages * uint64(os.Getpagesize())
+}
+
+// cpuStates возвращает доли состояний процессора между двумя снимками
+// в порядке us, sy, ni, id, wa, hi, si, st
+func cpuStates(prev, cur cpuTimes) [8]float64 {
+	var states [8]float64
+	total := float64(cur.total()) - float64(prev.total())
+	if total <= 0 {
+		states[3] = 100
+		return states
+	}
+	diff := func(a, b uint64) float64 { return max(float64(b)-float64(a), 0) * 100 / total }
+	states[0] = diff(prev.User, cur.User)
+	states[1] = diff(prev.System, cur.System)
+	states[2] = diff(prev.Nice, cur.Nice)
+	states[3] = diff(prev.Idle, cur.Idle)
+	states[4] = diff(prev.IOWait, cur.IOWait)
+	states[5] = diff(prev.IRQ, cur.IRQ)
+	states[6] = diff(prev.SoftIRQ, cur.SoftIRQ)
+	states[7] = diff(prev.Steal, cur.Steal)
+	return states
+}
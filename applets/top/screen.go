@@ -0,0 +1,329 @@
+package top
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/mir-yks/LinuxCommandAnalog/internal/display"
+	"github.com/mir-yks/LinuxCommandAnalog/internal/signals"
+	"github.com/mir-yks/LinuxCommandAnalog/internal/term"
+)
+
+// Управляющие последовательности терминала
+const (
+	cursorHome = "\033[H"
+	clearLine  = "\033[K"
+	clearBelow = "\033[J"
+)
+
+// headerLines - строки над списком: сводка, строка сообщений и заголовок
+// таблицы; messageRow - номер строки сообщений
+const (
+	headerLines = 7
+	messageRow  = 6
+)
+
+// screen - интерактивный режим top
+type screen struct {
+	config   *Config
+	prev     *sample
+	cur      *sample
+	offset   int    // первый видимый процесс
+	message  string // строка сообщений и запросов под сводкой
+	keys     chan string
+	out      *bufio.Writer
+	deadline time.Time // время следующего обновления
+}
+
+// monitor обновляет экран раз в Delay и обрабатывает клавиши
+func monitor(config *Config) error {
+	tty, err := term.OpenScreen()
+	if err != nil {
+		return err
+	}
+	defer tty.Close()
+
+	s := &screen{
+		config: config,
+		keys:   make(chan string),
+		out:    bufio.NewWriter(os.Stdout),
+	}
+	defer s.out.Flush()
+	go s.readKeys()
+
+	if s.prev, err = takeSample(nil); err != nil {
+		return err
+	}
+	s.deadline = time.Now().Add(warmup)
+
+	for frames := 0; ; {
+		select {
+		case key := <-s.keys:
+			if !s.handle(key) {
+				return nil
+			}
+			if s.cur != nil {
+				s.render()
+			}
+			continue
+		case <-time.After(time.Until(s.deadline)):
+		}
+
+		// Проценты считаются от последнего снимка, а не от того, что был
+		// перед ним: иначе каждый кадр показывал бы нагрузку за два интервала
+		base := s.cur
+		if base == nil {
+			base = s.prev
+		}
+		cur, err := takeSample(base)
+		if err != nil {
+			return err
+		}
+		s.prev, s.cur = base, cur
+		s.render()
+
+		frames++
+		if config.Iterations > 0 && frames >= config.Iterations {
+			return nil
+		}
+		s.deadline = time.Now().Add(config.Delay)
+	}
+}
+
+// readKeys читает нажатия клавиш и передает их в канал. Стрелки и другие
+// последовательности ESC [ приходят одним чтением и передаются словами.
+func (s *screen) readKeys() {
+	buf := make([]byte, 32)
+	for {
+		n, err := os.Stdin.Read(buf)
+		if err != nil {
+			s.keys <- "q"
+			return
+		}
+		data := string(buf[:n])
+		switch data {
+		case "\033[A", "\033OA":
+			s.keys <- "up"
+			continue
+		case "\033[B", "\033OB":
+			s.keys <- "down"
+			continue
+		case "\033[5~":
+			s.keys <- "pgup"
+			continue
+		case "\033[6~":
+			s.keys <- "pgdn"
+			continue
+		}
+		if strings.HasPrefix(data, "\033") && len(data) > 1 {
+			// Прочие последовательности не используются
+			continue
+		}
+		for _, r := range data {
+			s.keys <- string(r)
+		}
+	}
+}
+
+// handle выполняет действие клавиши; false означает выход
+func (s *screen) handle(key string) bool {
+	s.message = ""
+	switch key {
+	case "q", "\x03", "\x04":
+		return false
+	case "P":
+		s.config.Sort, s.config.Reverse = "cpu", false
+	case "M":
+		s.config.Sort, s.config.Reverse = "mem", false
+	case "N":
+		s.config.Sort, s.config.Reverse = "pid", false
+	case "T":
+		s.config.Sort, s.config.Reverse = "time", false
+	case "R":
+		s.config.Reverse = !s.config.Reverse
+	case "c":
+		s.config.FullCommand = !s.config.FullCommand
+	case "i":
+		s.config.HideIdle = !s.config.HideIdle
+	case "k":
+		s.kill()
+	case "r":
+		s.renice()
+	case "d":
+		s.changeDelay()
+	case "up":
+		s.offset--
+	case "down":
+		s.offset++
+	case "pgup":
+		s.offset -= term.ListHeight(headerLines)
+	case "pgdn":
+		s.offset += term.ListHeight(headerLines)
+	case " ", "\r", "\n":
+		s.deadline = time.Now()
+	case "h", "?":
+		s.message = "P M N T сортировка  R обратно  k сигнал  r nice  c команда  i простой  d пауза  q выход"
+	}
+	return true
+}
+
+// visible возвращает процессы текущего кадра в порядке вывода
+func (s *screen) visible() []*task {
+	if s.cur == nil {
+		return nil
+	}
+	return visibleTasks(s.cur, s.config)
+}
+
+// render перерисовывает экран целиком
+func (s *screen) render() {
+	if s.cur == nil {
+		// Первый кадр еще не готов
+		return
+	}
+	cols, _, ok := term.Size(os.Stdout.Fd())
+	if !ok {
+		cols = 80
+	}
+	height := term.ListHeight(headerLines)
+	tasks := s.visible()
+	s.offset = max(0, min(s.offset, len(tasks)-height))
+
+	out := s.out
+	out.WriteString(cursorHome)
+	for _, line := range header(s.prev, s.cur) {
+		out.WriteString(cut(line, cols) + clearLine + "\r\n")
+	}
+	out.WriteString(cut(s.message, cols) + clearLine + "\r\n")
+	title := cut(columnHeader(), cols)
+	out.WriteString(term.Reverse + title + strings.Repeat(" ", cols-len(title)) + term.Reset + "\r\n")
+
+	for row := 0; row < height && s.offset+row < len(tasks); row++ {
+		if row > 0 {
+			out.WriteString("\r\n")
+		}
+		out.WriteString(cut(formatTask(tasks[s.offset+row], s.cur, s.config), cols) + clearLine)
+	}
+	out.WriteString(clearBelow)
+	out.Flush()
+}
+
+// prompt показывает запрос в строке сообщений и читает ответ. Пустой
+// ответ заменяется значением по умолчанию; Esc отменяет ввод.
+func (s *screen) prompt(question, fallback string) (string, bool) {
+	var input []rune
+	s.out.WriteString(term.CursorShow)
+	defer s.out.WriteString(term.CursorHide)
+	for {
+		s.message = question + string(input)
+		s.render()
+		// Курсор - в конце запроса
+		fmt.Fprintf(s.out, "\033[%d;%dH", messageRow, display.String(s.message)+1)
+		s.out.Flush()
+
+		switch key := <-s.keys; key {
+		case "\033", "\x03":
+			s.message = ""
+			return "", false
+		case "\r", "\n":
+			s.message = ""
+			if len(input) == 0 {
+				return fallback, true
+			}
+			return string(input), true
+		case "\x7f", "\b":
+			if len(input) > 0 {
+				input = input[:len(input)-1]
+			}
+		default:
+			if r := []rune(key); len(r) == 1 && r[0] >= ' ' {
+				input = append(input, r[0])
+			}
+		}
+	}
+}
+
+// askPID запрашивает PID; по умолчанию - первый процесс списка
+func (s *screen) askPID(question string) (int, bool) {
+	fallback := ""
+	if tasks := s.visible(); len(tasks) > 0 {
+		fallback = strconv.Itoa(tasks[s.offset].PID)
+	}
+	answer, ok := s.prompt(fmt.Sprintf("%s [%s]: ", question, fallback), fallback)
+	if !ok {
+		return 0, false
+	}
+	pid, err := strconv.Atoi(strings.TrimSpace(answer))
+	if err != nil || pid <= 0 {
+		s.message = fmt.Sprintf("Неверный PID '%s'", answer)
+		return 0, false
+	}
+	return pid, true
+}
+
+// kill отправляет сигнал выбранному процессу
+func (s *screen) kill() {
+	pid, ok := s.askPID("PID для сигнала")
+	if !ok {
+		return
+	}
+	answer, ok := s.prompt(fmt.Sprintf("Отправить PID %d сигнал [15/TERM]: ", pid), "TERM")
+	if !ok {
+		return
+	}
+	signal, err := signals.Parse(strings.TrimSpace(answer))
+	if err != nil {
+		s.message = fmt.Sprintf("Неверный сигнал '%s'", answer)
+		return
+	}
+	if err := syscall.Kill(pid, signal); err != nil {
+		s.message = fmt.Sprintf("Не удалось отправить сигнал %d процессу %d: %v", signal, pid, err)
+		return
+	}
+	s.message = fmt.Sprintf("Сигнал %d отправлен процессу %d", signal, pid)
+	s.deadline = time.Now()
+}
+
+// renice меняет nice выбранного процесса
+func (s *screen) renice() {
+	pid, ok := s.askPID("PID для изменения nice")
+	if !ok {
+		return
+	}
+	answer, ok := s.prompt(fmt.Sprintf("Новое значение nice для PID %d [10]: ", pid), "10")
+	if !ok {
+		return
+	}
+	nice, err := strconv.Atoi(strings.TrimSpace(answer))
+	if err != nil || nice < -20 || nice > 19 {
+		s.message = fmt.Sprintf("Неверное значение nice '%s': нужно от -20 до 19", answer)
+		return
+	}
+	if err := syscall.Setpriority(syscall.PRIO_PROCESS, pid, nice); err != nil {
+		s.message = fmt.Sprintf("Не удалось изменить nice процесса %d: %v", pid, err)
+		return
+	}
+	s.message = fmt.Sprintf("Nice процесса %d: %d", pid, nice)
+	s.deadline = time.Now()
+}
+
+// changeDelay меняет паузу между обновлениями
+func (s *screen) changeDelay() {
+	current := strconv.FormatFloat(s.config.Delay.Seconds(), 'f', -1, 64)
+	answer, ok := s.prompt(fmt.Sprintf("Пауза в секундах [%s]: ", current), current)
+	if !ok {
+		return
+	}
+	delay, err := parseDelay(strings.TrimSpace(answer))
+	if err != nil {
+		s.message = fmt.Sprintf("Неверная пауза '%s'", answer)
+		return
+	}
+	s.config.Delay = delay
+	s.deadline = time.Now().Add(delay)
+}
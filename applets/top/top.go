@@ -0,0 +1,256 @@
+package top
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mir-yks/LinuxCommandAnalog/internal/applet"
+	"github.com/mir-yks/LinuxCommandAnalog/internal/getopt"
+	"github.com/mir-yks/LinuxCommandAnalog/internal/proc"
+	"github.com/mir-yks/LinuxCommandAnalog/internal/term"
+)
+
+type Config struct {
+	Help        bool
+	Version     bool
+	Batch       bool          // -b: вывод кадров подряд, без управления терминалом
+	Iterations  int           // -n: число обновлений; 0 - без ограничения
+	Delay       time.Duration // -d: пауза между обновлениями
+	Sort        string        // cpu, mem, pid или time
+	Reverse     bool          // обратный порядок сортировки
+	PIDs        []int         // -p: показывать только эти процессы
+	UID         int           // -u: только процессы пользователя; -1 - все
+	FullCommand bool          // -c: командная строка вместо имени
+	HideIdle    bool          // -i: скрыть процессы, не занимавшие процессор
+}
+
+const ver = "1.0.0"
+
+// warmup - пауза между двумя снимками перед первым выводом: без нее
+// загрузку процессора не с чем сравнить
+const warmup = 500 * time.Millisecond
+
+func init() {
+	applet.Register("top", Main)
+}
+
+// Main запускает утилиту top
+func Main() {
+	defer func() {
+		if r := recover(); r != nil {
+			fmt.Fprintf(os.Stderr, "top: %v\n", r)
+			os.Exit(1)
+		}
+	}()
+
+	config := parseArgs()
+
+	if config.Help {
+		printHelp()
+		return
+	}
+
+	if config.Version {
+		printVersion()
+		return
+	}
+
+	if err := executeTop(config); err != nil {
+		fmt.Fprintf(os.Stderr, "top: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// parseArgs разбирает аргументы командной строки
+func parseArgs() *Config {
+	config := &Config{Delay: 3 * time.Second, Sort: "cpu", UID: -1}
+
+	opts := getopt.New("top")
+	opts.Bool(&config.Help, 'h', "help")
+	opts.Bool(&config.Version, 'v', "version")
+	opts.Bool(&config.Batch, 'b', "batch")
+	opts.Bool(&config.FullCommand, 'c', "cmdline")
+	opts.Bool(&config.HideIdle, 'i', "idle")
+	opts.Func('n', "iterations", getopt.RequiredArgument, func(value string) error {
+		n, err := strconv.Atoi(value)
+		if err != nil || n <= 0 {
+			return fmt.Errorf("неверное число обновлений '%s'", value)
+		}
+		config.Iterations = n
+		return nil
+	})
+	opts.Func('d', "delay", getopt.RequiredArgument, func(value string) error {
+		delay, err := parseDelay(value)
+		if err != nil {
+			return err
+		}
+		config.Delay = delay
+		return nil
+	})
+	opts.Func('o', "sort-override", getopt.RequiredArgument, func(value string) error {
+		key, reverse, err := parseSortKey(value)
+		if err != nil {
+			return err
+		}
+		config.Sort, config.Reverse = key, reverse
+		return nil
+	})
+	opts.Func('p', "pid", getopt.RequiredArgument, func(value string) error {
+		for _, item := range strings.Split(value, ",") {
+			pid, err := strconv.Atoi(item)
+			if err != nil || pid <= 0 {
+				return fmt.Errorf("неверный PID '%s'", item)
+			}
+			config.PIDs = append(config.PIDs, pid)
+		}
+		return nil
+	})
+	opts.Func('u', "user", getopt.RequiredArgument, func(value string) error {
+		uid, err := proc.LookupUID(value)
+		if err != nil {
+			return err
+		}
+		config.UID = uid
+		return nil
+	})
+	args := opts.Parse(os.Args[1:])
+
+	if len(args) > 0 {
+		opts.Failf("неизвестный аргумент '%s'", args[0])
+	}
+
+	return config
+}
+
+// parseDelay разбирает паузу в секундах, можно дробную: 0.5
+func parseDelay(value string) (time.Duration, error) {
+	seconds, err := strconv.ParseFloat(value, 64)
+	if err != nil || seconds <= 0 {
+		return 0, fmt.Errorf("неверная задержка '%s'", value)
+	}
+	return time.Duration(seconds * float64(time.Second)), nil
+}
+
+// parseSortKey разбирает поле сортировки: cpu, mem, pid, time или имя
+// столбца procps (%CPU, %MEM, TIME+); "+" перед именем - по убыванию,
+// "-" - по возрастанию
+func parseSortKey(value string) (key string, reverse bool, err error) {
+	name := strings.ToLower(value)
+	desc := true
+	switch {
+	case strings.HasPrefix(name, "+"):
+		name = name[1:]
+	case strings.HasPrefix(name, "-"):
+		name, desc = name[1:], false
+	}
+	if alias, ok := sortNames[name]; ok {
+		name = alias
+	}
+	if _, ok := sortKeys[name]; !ok {
+		return "", false, fmt.Errorf("неизвестное поле сортировки '%s'", value)
+	}
+	// Порядок по умолчанию у PID - по возрастанию, у остальных - по убыванию
+	natural := name != "pid"
+	return name, desc != natural, nil
+}
+
+// printHelp выводит справку
+func printHelp() {
+	fmt.Println("top - показывает процессы и загрузку системы в реальном времени")
+	fmt.Println()
+	fmt.Println("Использование: top [ОПЦИЯ]...")
+	fmt.Println()
+	fmt.Println("Загрузка процессора считается по двум снимкам /proc: сколько тактов")
+	fmt.Println("процесс получил между обновлениями, в процентах одного процессора.")
+	fmt.Println()
+	fmt.Println("Опции:")
+	fmt.Println("  -b, --batch             пакетный режим: кадры выводятся подряд, клавиши")
+	fmt.Println("                          не читаются (для журналов и конвейеров)")
+	fmt.Println("  -n, --iterations=N      завершиться после N обновлений")
+	fmt.Println("  -d, --delay=СЕК         пауза между обновлениями (по умолчанию 3, можно 0.5)")
+	fmt.Println("  -o, --sort-override=ПОЛЕ")
+	fmt.Printf("                          сортировка: cpu, mem, pid, time (или %%CPU, %%MEM,\n")
+	fmt.Println("                          PID, TIME+); '+' - по убыванию, '-' - по возрастанию")
+	fmt.Println("  -p, --pid=PID,...       показывать только эти процессы")
+	fmt.Println("  -u, --user=ПОЛЬЗОВАТЕЛЬ показывать только процессы пользователя")
+	fmt.Println("  -c, --cmdline           командная строка вместо имени процесса")
+	fmt.Println("  -i, --idle              скрыть процессы, не занимавшие процессор")
+	fmt.Println("  -h, --help              показать эту справку")
+	fmt.Println("  -v, --version           показать информацию о версии")
+	fmt.Println()
+	fmt.Println("Клавиши:")
+	fmt.Println("  P, M, N, T              сортировка по процессору, памяти, PID, времени")
+	fmt.Println("  R                       обратный порядок сортировки")
+	fmt.Println("  k                       отправить сигнал процессу")
+	fmt.Println("  r                       изменить nice процесса")
+	fmt.Println("  c, i                    переключить -c и -i")
+	fmt.Println("  d                       изменить паузу")
+	fmt.Println("  ↑ ↓ PgUp PgDn           прокрутка списка")
+	fmt.Println("  пробел                  обновить сейчас")
+	fmt.Println("  q                       выход")
+	fmt.Println()
+	fmt.Println("Примеры:")
+	fmt.Println("  top                         # Интерактивный режим")
+	fmt.Println("  top -o mem                  # Сортировка по памяти")
+	fmt.Println("  top -b -n 5 -d 10 > top.log # Пять кадров раз в 10 секунд в файл")
+	fmt.Println("  top -u www-data -c          # Процессы пользователя с командной строкой")
+}
+
+// printVersion выводит информацию о версии
+func printVersion() {
+	fmt.Println("top версия", ver)
+	fmt.Println("Разработано в рамках учебного проекта")
+	fmt.Println("Язык программирования: Golang")
+}
+
+// executeTop запускает интерактивный режим, если ввод и вывод - терминал,
+// иначе пакетный
+func executeTop(config *Config) error {
+	if !config.Batch && term.IsTerminal(os.Stdin.Fd()) && term.IsTerminal(os.Stdout.Fd()) {
+		return monitor(config)
+	}
+	return batch(config)
+}
+
+// batch выводит кадры подряд: сводку и полный список процессов
+func batch(config *Config) error {
+	prev, err := takeSample(nil)
+	if err != nil {
+		return err
+	}
+	time.Sleep(warmup)
+
+	w := bufio.NewWriter(os.Stdout)
+	for i := 1; ; i++ {
+		cur, err := takeSample(prev)
+		if err != nil {
+			return err
+		}
+
+		if i > 1 {
+			fmt.Fprintln(w)
+		}
+		for _, line := range header(prev, cur) {
+			fmt.Fprintln(w, line)
+		}
+		fmt.Fprintln(w)
+		fmt.Fprintln(w, columnHeader())
+		for _, t := range visibleTasks(cur, config) {
+			fmt.Fprintln(w, formatTask(t, cur, config))
+		}
+		// Кадр целиком попадает в журнал до паузы
+		if err := w.Flush(); err != nil {
+			return err
+		}
+
+		if config.Iterations > 0 && i >= config.Iterations {
+			return nil
+		}
+		prev = cur
+		time.Sleep(config.Delay)
+	}
+}
@@ -1,9 +1,12 @@
-package main
+package touch
 
 import (
 	"fmt"
 	"os"
 	"time"
+
+	"github.com/mir-yks/LinuxCommandAnalog/internal/applet"
+	"github.com/mir-yks/LinuxCommandAnalog/internal/getopt"
 )
 
 const ver = "1.0.0"
@@ -16,7 +19,12 @@ type Config struct {
 	Filenames []string
 }
 
-func main() {
+func init() {
+	applet.Register("touch", Main)
+}
+
+// Main запускает утилиту touch
+func Main() {
 	defer func() {
 		if r := recover(); r != nil {
 			fmt.Fprintf(os.Stderr, "Ошибка: %v\n", r)
@@ -36,60 +44,27 @@ func main() {
 		return
 	}
 
-	if len(config.Filenames) == 0 {
-		fmt.Fprintln(os.Stderr, "touch: пропущен операнд, задающий файл")
-		fmt.Fprintln(os.Stderr, "По команде «touch -h» можно получить дополнительную информацию.")
-		os.Exit(1)
-	}
-
-	err := executeTouch(config)
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "Ошибка: %v\n", err)
+	if !executeTouch(config) {
 		os.Exit(1)
 	}
 }
 
 func parseArgs() *Config {
 	config := &Config{}
-	filenames := []string{}
-
-	for i := 1; i < len(os.Args); i++ {
-		arg := os.Args[i]
-
-		switch arg {
-		case "-h":
-			config.Help = true
-		case "-v":
-			config.Version = true
-		case "--version":
-			config.Version = true
-		case "-a":
-			config.Access = true
-		case "-m":
-			config.Modify = true
-		default:
-			if len(arg) > 1 && arg[0] == '-' {
-				for _, ch := range arg[1:] {
-					switch ch {
-					case 'h':
-						config.Help = true
-					case 'v':
-						config.Version = true
-					case 'a':
-						config.Access = true
-					case 'm':
-						config.Modify = true
-					default:
-						panic(fmt.Sprintf("touch: неверный ключ — '%s'", arg))
-					}
-				}
-			} else {
-				filenames = append(filenames, arg)
-			}
-		}
-	}
 
-	config.Filenames = filenames
+	opts := getopt.New("touch")
+	opts.Bool(&config.Help, 'h', "help")
+	opts.Bool(&config.Version, 'v', "version")
+	opts.Bool(&config.Access, 'a', "")
+	opts.Bool(&config.Modify, 'm', "")
+	config.Filenames = opts.Parse(os.Args[1:])
+
+	if config.Help || config.Version {
+		return config
+	}
+	if len(config.Filenames) == 0 {
+		opts.Failf("пропущен операнд, задающий файл")
+	}
 	return config
 }
 
@@ -120,18 +95,21 @@ func printVersion() {
 	fmt.Println("Язык программирования: Golang")
 }
 
-// executeTouch выполняет основную логику команды touch
-func executeTouch(config *Config) error {
+// executeTouch выполняет основную логику команды touch; false - если
+// хотя бы один файл обработать не удалось
+func executeTouch(config *Config) bool {
 	now := time.Now()
+	ok := true
 
 	for _, filename := range config.Filenames {
 		err := processFile(filename, config, now)
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "touch: ошибка при обработке файла '%s': %v\n", filename, err)
+			ok = false
 		}
 	}
 
-	return nil
+	return ok
 }
 
 // processFile обрабатывает один файл
@@ -1,9 +1,12 @@
-package main
+package uname
 
 import (
 	"fmt"
 	"os"
 	"runtime"
+
+	"github.com/mir-yks/LinuxCommandAnalog/internal/applet"
+	"github.com/mir-yks/LinuxCommandAnalog/internal/getopt"
 )
 
 type Config struct {
@@ -16,7 +19,12 @@ type Config struct {
 
 const ver = "1.0.0"
 
-func main() {
+func init() {
+	applet.Register("uname", Main)
+}
+
+// Main запускает утилиту uname
+func Main() {
 	defer func() {
 		if r := recover(); r != nil {
 			fmt.Fprintf(os.Stderr, "Ошибка: %v\n", r)
@@ -43,58 +51,20 @@ func main() {
 	executeUname(config)
 }
 
-// parseArgs разбирает аргументы командной строки вручную
+// parseArgs разбирает аргументы командной строки
 func parseArgs() *Config {
 	config := &Config{}
-	i := 1
-
-	for i < len(os.Args) {
-		arg := os.Args[i]
-
-		switch arg {
-		case "-h":
-			config.Help = true
-			i++
-			continue
-		case "-v", "--version":
-			config.Version = true
-			i++
-			continue
-		case "-a":
-			config.All = true
-			i++
-			continue
-		case "-s":
-			config.Kernel = true
-			i++
-			continue
-		case "-n":
-			config.Host = true
-			i++
-			continue
-		default:
-			if len(arg) > 1 && arg[0] == '-' {
-				for _, ch := range arg[1:] {
-					switch ch {
-					case 'a':
-						config.All = true
-					case 's':
-						config.Kernel = true
-					case 'n':
-						config.Host = true
-					case 'v':
-						config.Version = true
-					case 'h':
-						config.Help = true
-					default:
-						panic(fmt.Sprintf("uname: неверный ключ — '%s'", arg))
-					}
-				}
-				i++
-				continue
-			}
-			panic(fmt.Sprintf("неожиданный аргумент: %s", arg))
-		}
+
+	opts := getopt.New("uname")
+	opts.Bool(&config.Help, 'h', "help")
+	opts.Bool(&config.Version, 'v', "version")
+	opts.Bool(&config.All, 'a', "all")
+	opts.Bool(&config.Kernel, 's', "kernel-name")
+	opts.Bool(&config.Host, 'n', "nodename")
+	args := opts.Parse(os.Args[1:])
+
+	if len(args) > 0 {
+		opts.Failf("лишний операнд '%s'", args[0])
 	}
 
 	return config
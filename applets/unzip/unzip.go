@@ -1,4 +1,4 @@
-package main
+package unzip
 
 import (
 	"archive/zip"
@@ -6,6 +6,9 @@ import (
 	"io"
 	"os"
 	"path/filepath"
+
+	"github.com/mir-yks/LinuxCommandAnalog/internal/applet"
+	"github.com/mir-yks/LinuxCommandAnalog/internal/getopt"
 )
 
 type Config struct {
@@ -17,7 +20,12 @@ type Config struct {
 
 const ver = "1.0.0"
 
-func main() {
+func init() {
+	applet.Register("unzip", Main)
+}
+
+// Main запускает утилиту unzip
+func Main() {
 	defer func() {
 		if r := recover(); r != nil {
 			fmt.Fprintf(os.Stderr, "Ошибка: %v\n", r)
@@ -33,21 +41,10 @@ func main() {
 	}
 
 	if config.List {
-		if config.Archive == "" {
-			fmt.Fprintln(os.Stderr, "unzip: пропущен архив")
-			fmt.Fprintln(os.Stderr, "По команде «unzip -h» можно получить дополнительную информацию.")
-			os.Exit(1)
-		}
 		executeList(config)
 		return
 	}
 
-	if config.Archive == "" {
-		fmt.Fprintln(os.Stderr, "unzip: пропущен архив")
-		fmt.Fprintln(os.Stderr, "По команде «unzip -h» можно получить дополнительную информацию.")
-		os.Exit(1)
-	}
-
 	if config.OutputDir == "" {
 		config.OutputDir = "."
 	}
@@ -56,67 +53,24 @@ func main() {
 }
 
 
-// parseArgs разбирает аргументы командной строки вручную
+// parseArgs разбирает аргументы командной строки
 func parseArgs() *Config {
 	config := &Config{}
-	i := 1
 
-	for i < len(os.Args) {
-		arg := os.Args[i]
+	opts := getopt.New("unzip")
+	opts.Bool(&config.Help, 'h', "help")
+	opts.Bool(&config.List, 'l', "")
+	opts.String(&config.Archive, 'f', "")
+	opts.String(&config.OutputDir, 'o', "")
+	args := opts.Parse(os.Args[1:])
 
-		switch arg {
-		case "-h":
-			config.Help = true
-			i++
-			continue
-		case "-l":
-			config.List = true
-			i++
-			continue
-		case "-f":
-			if i+1 < len(os.Args) {
-				config.Archive = os.Args[i+1]
-				i += 2
-				continue
-			} else {
-				panic("не указан архив после -f")
-			}
-		case "-o":
-			if i+1 < len(os.Args) {
-				config.OutputDir = os.Args[i+1]
-				i += 2
-				continue
-			} else {
-				panic("не указана папка после -o")
-			}
-		default:
-			if len(arg) > 1 && arg[0] == '-' {
-				for _, ch := range arg[1:] {
-					switch ch {
-					case 'h':
-						config.Help = true
-					case 'l':
-						config.List = true
-					case 'f':
-						panic("параметр -f требует указания архива")
-					case 'o':
-						panic("параметр -o требует указания папки")
-					default:
-						panic(fmt.Sprintf("unzip: неверный ключ — '%s'", arg))
-					}
-				}
-				i++
-				continue
-			}
-			if config.Archive == "" {
-				config.Archive = arg
-				i++
-				continue
-			}
-			i++
-		}
+	if config.Archive == "" && len(args) > 0 {
+		config.Archive = args[0]
 	}
 
+	if !config.Help && config.Archive == "" {
+		opts.Failf("пропущен архив")
+	}
 	return config
 }
 
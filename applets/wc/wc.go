@@ -0,0 +1,453 @@
+package wc
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/mir-yks/LinuxCommandAnalog/internal/applet"
+	"github.com/mir-yks/LinuxCommandAnalog/internal/display"
+	"github.com/mir-yks/LinuxCommandAnalog/internal/getopt"
+	"github.com/mir-yks/LinuxCommandAnalog/internal/input"
+)
+
+type Config struct {
+	Help       bool
+	Version    bool
+	Lines      bool   // -l
+	Words      bool   // -w
+	Chars      bool   // -m: символы UTF-8
+	Bytes      bool   // -c
+	MaxLine    bool   // -L: ширина самой длинной строки на экране
+	Total      string // --total: auto, always, only или never
+	Files0From string // --files0-from: имена файлов через NUL
+	Filenames  []string
+}
+
+const ver = "1.0.0"
+
+func init() {
+	applet.Register("wc", Main)
+}
+
+// Main запускает утилиту wc
+func Main() {
+	defer func() {
+		if r := recover(); r != nil {
+			fmt.Fprintf(os.Stderr, "Ошибка: %v\n", r)
+			os.Exit(1)
+		}
+	}()
+
+	config := parseArgs()
+
+	if config.Help {
+		printHelp()
+		return
+	}
+
+	if config.Version {
+		printVersion()
+		return
+	}
+
+	if !executeWc(config) {
+		os.Exit(1)
+	}
+}
+
+// parseArgs разбирает аргументы командной строки
+func parseArgs() *Config {
+	config := &Config{Total: "auto"}
+
+	opts := getopt.New("wc")
+	opts.Bool(&config.Help, 'h', "help")
+	opts.Bool(&config.Version, 'v', "version")
+	opts.Bool(&config.Bytes, 'c', "bytes")
+	opts.Bool(&config.Chars, 'm', "chars")
+	opts.Bool(&config.Lines, 'l', "lines")
+	opts.Bool(&config.Words, 'w', "words")
+	opts.Bool(&config.MaxLine, 'L', "max-line-length")
+	opts.String(&config.Files0From, 0, "files0-from")
+	opts.Func(0, "total", getopt.RequiredArgument, func(value string) error {
+		switch value {
+		case "auto", "always", "only", "never":
+			config.Total = value
+			return nil
+		}
+		return fmt.Errorf("неверное значение '%s': ожидается auto, always, only или never", value)
+	})
+	config.Filenames = opts.Parse(os.Args[1:])
+
+	if config.Files0From != "" && len(config.Filenames) > 0 {
+		opts.Failf("лишний операнд '%s': с --files0-from файлы задаются только в списке", config.Filenames[0])
+	}
+	if !config.Bytes && !config.Chars && !config.Lines && !config.Words && !config.MaxLine {
+		config.Lines, config.Words, config.Bytes = true, true, true
+	}
+
+	return config
+}
+
+// printHelp выводит справку
+func printHelp() {
+	fmt.Println("wc - подсчитывает количество строк, слов и байтов")
+	fmt.Println()
+	fmt.Println("Использование: wc [ОПЦИЯ]... [ФАЙЛ]...")
+	fmt.Println("         или: wc [ОПЦИЯ]... --files0-from=F")
+	fmt.Println()
+	fmt.Println("Счетчики выводятся в порядке: строки, слова, символы, байты, длина самой")
+	fmt.Println("длинной строки. Для нескольких файлов добавляется строка total.")
+	fmt.Println()
+	fmt.Println("Опции:")
+	fmt.Println("  -l, --lines            количество строк (переводов строки)")
+	fmt.Println("  -w, --words            количество слов, разделенных пробельными символами")
+	fmt.Println("  -m, --chars            количество символов UTF-8; неверные байты не")
+	fmt.Println("                         считаются символами")
+	fmt.Println("  -c, --bytes            количество байтов")
+	fmt.Println("  -L, --max-line-length  ширина самой длинной строки на экране: широкие")
+	fmt.Println("                         символы занимают две колонки, табуляция - до")
+	fmt.Println("                         следующей кратной 8")
+	fmt.Println("      --files0-from=F    читать имена файлов из F, разделенные NUL;")
+	fmt.Println("                         F = - - стандартный ввод")
+	fmt.Println("      --total=КОГДА      строка total: auto (для нескольких файлов),")
+	fmt.Println("                         always, only (только она), never")
+	fmt.Println("  -h, --help             показать эту справку")
+	fmt.Println("  -v, --version          показать информацию о версии")
+	fmt.Println()
+	fmt.Println("По умолчанию выводятся строки, слова и байты. Если ФАЙЛ не задан")
+	fmt.Println("или задан как -, читается стандартный ввод.")
+	fmt.Println()
+	fmt.Println("Примеры:")
+	fmt.Println("  wc file.txt              # Строки, слова и байты")
+	fmt.Println("  wc -l *.go               # Строки в каждом файле и итог")
+	fmt.Println("  wc -m текст.txt          # Символы, а не байты")
+	fmt.Println("  wc -L file.txt           # Ширина самой длинной строки")
+	fmt.Println("  find . -name '*.md' -print0 | wc -w --files0-from=-")
+}
+
+// printVersion выводит информацию о версии
+func printVersion() {
+	fmt.Println("wc версия", ver)
+	fmt.Println("Разработано в рамках учебного проекта")
+	fmt.Println("Язык программирования: Golang")
+}
+
+// FileStats структура для хранения статистики файла
+type FileStats struct {
+	Lines   int64
+	Words   int64
+	Chars   int64
+	Bytes   int64
+	MaxLine int64
+}
+
+// add прибавляет статистику файла к итогу; для -L берется наибольшее значение
+func (s *FileStats) add(other *FileStats) {
+	s.Lines += other.Lines
+	s.Words += other.Words
+	s.Chars += other.Chars
+	s.Bytes += other.Bytes
+	s.MaxLine = max(s.MaxLine, other.MaxLine)
+}
+
+// executeWc считает файлы и выводит таблицу; false - если были ошибки
+func executeWc(config *Config) bool {
+	ok := true
+	names := config.Filenames
+	if config.Files0From != "" {
+		var err error
+		if names, err = readFiles0(config.Files0From); err != nil {
+			fmt.Fprintf(os.Stderr, "wc: %v\n", err)
+			return false
+		}
+	}
+
+	// Без операндов читается стандартный ввод, и имя не выводится
+	showNames := true
+	if len(names) == 0 && config.Files0From == "" {
+		names, showNames = input.Operands(nil), false
+	}
+
+	width := numberWidth(names, config)
+	var total FileStats
+	for _, name := range names {
+		if name == "" {
+			fmt.Fprintf(os.Stderr, "wc: пустое имя файла в списке\n")
+			ok = false
+			continue
+		}
+		stats, err := countFile(name, config)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "wc: %v\n", err)
+			ok = false
+		}
+		if stats == nil {
+			continue
+		}
+		total.add(stats)
+		if config.Total != "only" {
+			label := ""
+			if showNames {
+				label = name
+			}
+			printStats(label, stats, width, config)
+		}
+	}
+
+	switch config.Total {
+	case "always", "only":
+	case "auto":
+		if len(names) < 2 {
+			return ok
+		}
+	default:
+		return ok
+	}
+	label := "total"
+	if config.Total == "only" {
+		label = ""
+	}
+	printStats(label, &total, width, config)
+	return ok
+}
+
+// readFiles0 читает список файлов, разделенных NUL
+func readFiles0(name string) ([]string, error) {
+	var data []byte
+	var err error
+	if name == input.Stdin {
+		data, err = io.ReadAll(os.Stdin)
+	} else {
+		data, err = os.ReadFile(name)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("не удается прочитать список файлов '%s': %v", name, input.Describe(err))
+	}
+
+	names := strings.Split(string(data), "\x00")
+	if len(names) > 0 && names[len(names)-1] == "" {
+		names = names[:len(names)-1]
+	}
+	if name == input.Stdin {
+		for _, file := range names {
+			if file == input.Stdin {
+				return nil, fmt.Errorf("список файлов читается со стандартного ввода, поэтому '-' в нем недопустим")
+			}
+		}
+	}
+	return names, nil
+}
+
+// numberWidth вычисляет ширину столбцов, как GNU wc: по числу цифр в
+// суммарном размере обычных файлов. Ни один счетчик не больше размера
+// файла в байтах, поэтому столбцы всех строк совпадают. Для каналов и
+// устройств размер заранее неизвестен, и ширина не меньше 7. Один
+// счетчик одного файла выводится без выравнивания.
+func numberWidth(names []string, config *Config) int {
+	counters := 0
+	for _, on := range []bool{config.Lines, config.Words, config.Chars, config.Bytes, config.MaxLine} {
+		if on {
+			counters++
+		}
+	}
+	if len(names) == 1 && counters == 1 {
+		return 1
+	}
+
+	width, minimum := 1, 1
+	var size int64
+	for _, name := range names {
+		var info os.FileInfo
+		var err error
+		if name == input.Stdin {
+			info, err = os.Stdin.Stat()
+		} else {
+			info, err = os.Stat(name)
+		}
+		switch {
+		case err != nil:
+		case info.Mode().IsRegular():
+			size += info.Size()
+		default:
+			minimum = 7
+		}
+	}
+	for ; size >= 10; size /= 10 {
+		width++
+	}
+	return max(width, minimum)
+}
+
+// countFile подсчитывает статистику файла. При ошибке чтения возвращается
+// то, что успели посчитать, как в GNU wc; nil - если файл не открылся.
+func countFile(filename string, config *Config) (*FileStats, error) {
+	file, err := input.Open(filename)
+	if err != nil {
+		return nil, input.OpenError(filename, err)
+	}
+	defer file.Close()
+
+	stats := &FileStats{}
+	switch {
+	case config.Words || config.Chars || config.MaxLine:
+		err = countText(file, stats)
+	case config.Lines:
+		err = countLines(file, stats)
+	default:
+		err = countBytes(file, stats)
+	}
+	if err != nil {
+		return stats, input.ReadError(filename, err)
+	}
+	return stats, nil
+}
+
+// countBytes считает только байты: у обычного файла размер известен заранее
+func countBytes(file *input.File, stats *FileStats) error {
+	if file.Seekable() {
+		info, err := file.Stat()
+		if err != nil {
+			return err
+		}
+		// Стандартный ввод может быть уже частично прочитан
+		pos, err := file.Seek(0, io.SeekCurrent)
+		if err == nil && info.Size() >= pos {
+			stats.Bytes = info.Size() - pos
+			return nil
+		}
+	}
+	n, err := io.Copy(io.Discard, file)
+	stats.Bytes = n
+	return err
+}
+
+// countLines считает строки и байты без разбора символов
+func countLines(file *input.File, stats *FileStats) error {
+	buf := make([]byte, 64*1024)
+	for {
+		n, err := file.Read(buf)
+		stats.Bytes += int64(n)
+		stats.Lines += int64(bytes.Count(buf[:n], []byte{'\n'}))
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// tabWidth - шаг табуляции для -L
+const tabWidth = 8
+
+// countText считает все счетчики, разбирая UTF-8. Последовательность,
+// разрезанная границей буфера, переносится в следующее чтение.
+func countText(file *input.File, stats *FileStats) error {
+	buf := make([]byte, 64*1024)
+	carry := 0
+	inWord := false
+	var pos int64 // ширина текущей строки на экране
+
+	endLine := func() {
+		stats.MaxLine = max(stats.MaxLine, pos)
+		pos = 0
+	}
+
+	for {
+		n, err := file.Read(buf[carry:])
+		stats.Bytes += int64(n)
+		data := buf[:carry+n]
+		eof := err != nil
+
+		i := 0
+		for i < len(data) {
+			c := data[i]
+			r, size := rune(c), 1
+			valid := true
+			if c >= utf8.RuneSelf {
+				if !eof && !utf8.FullRune(data[i:]) {
+					break
+				}
+				r, size = utf8.DecodeRune(data[i:])
+				valid = r != utf8.RuneError || size > 1
+			}
+			i += size
+
+			if !valid {
+				// Неверный байт не символ и не разделитель слов, ширины не имеет
+				continue
+			}
+			stats.Chars++
+
+			switch r {
+			case '\n':
+				stats.Lines++
+				endLine()
+			case '\r', '\f':
+				endLine()
+			case '\t':
+				pos += tabWidth - pos%tabWidth
+			default:
+				pos += int64(display.RuneWidth(r))
+			}
+
+			switch {
+			case isSpace(r):
+				inWord = false
+			case r >= ' ' && !(r >= 0x7f && r < 0xa0):
+				// Управляющие символы не начинают слово и не разделяют слова
+				if !inWord {
+					stats.Words++
+					inWord = true
+				}
+			}
+		}
+		carry = copy(buf, data[i:])
+
+		if err == io.EOF {
+			endLine()
+			return nil
+		}
+		if err != nil {
+			endLine()
+			return err
+		}
+	}
+}
+
+// isSpace сообщает, что символ разделяет слова. Кроме пробельных символов
+// Unicode, как в GNU wc, разделителями считаются неразрывные пробелы и
+// WORD JOINER.
+func isSpace(r rune) bool {
+	return unicode.IsSpace(r) || r == 0x2007 || r == 0x202F || r == 0x2060
+}
+
+// printStats выводит строку таблицы: счетчики в порядке GNU wc и имя
+func printStats(filename string, stats *FileStats, width int, config *Config) {
+	var columns []string
+	for _, counter := range []struct {
+		on    bool
+		value int64
+	}{
+		{config.Lines, stats.Lines},
+		{config.Words, stats.Words},
+		{config.Chars, stats.Chars},
+		{config.Bytes, stats.Bytes},
+		{config.MaxLine, stats.MaxLine},
+	} {
+		if counter.on {
+			value := strconv.FormatInt(counter.value, 10)
+			columns = append(columns, strings.Repeat(" ", max(0, width-len(value)))+value)
+		}
+	}
+	if filename != "" {
+		columns = append(columns, filename)
+	}
+	fmt.Println(strings.Join(columns, " "))
+}
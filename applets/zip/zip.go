@@ -1,4 +1,4 @@
-package main
+package zip
 
 import (
 	"archive/zip"
@@ -6,6 +6,9 @@ import (
 	"io"
 	"os"
 	"path/filepath"
+
+	"github.com/mir-yks/LinuxCommandAnalog/internal/applet"
+	"github.com/mir-yks/LinuxCommandAnalog/internal/getopt"
 )
 
 type Config struct {
@@ -19,7 +22,12 @@ type Config struct {
 
 const ver = "1.0.0"
 
-func main() {
+func init() {
+	applet.Register("zip", Main)
+}
+
+// Main запускает утилиту zip
+func Main() {
 	defer func() {
 		if r := recover(); r != nil {
 			fmt.Fprintf(os.Stderr, "Ошибка: %v\n", r)
@@ -39,71 +47,31 @@ func main() {
 		return
 	}
 
-	if config.Archive == "" || len(config.Filenames) == 0 {
-		fmt.Fprintln(os.Stderr, "zip: пропущен архив или файлы")
-		fmt.Fprintln(os.Stderr, "По команде «zip -h» можно получить дополнительную информацию.")
-		os.Exit(1)
-	}
-
 	executeZip(config)
 }
 
-// parseArgs разбирает аргументы командной строки вручную
+// parseArgs разбирает аргументы командной строки
 func parseArgs() *Config {
 	config := &Config{}
-	i := 1
-
-	for i < len(os.Args) {
-		arg := os.Args[i]
-
-		switch arg {
-		case "-h":
-			config.Help = true
-			i++
-			continue
-		case "-v", "--version":
-			config.Version = true
-			i++
-			continue
-		case "-d":
-			config.Delete = true
-			i++
-			continue
-		case "-u":
-			config.Update = true
-			i++
-			continue
-		default:
-			if len(arg) > 1 && arg[0] == '-' {
-				for _, ch := range arg[1:] {
-					switch ch {
-					case 'h':
-						config.Help = true
-					case 'v':
-						config.Version = true
-					case 'd':
-						config.Delete = true
-					case 'u':
-						config.Update = true
-					default:
-						panic(fmt.Sprintf("zip: неверный ключ — '%s'", arg))
-					}
-				}
-				i++
-				continue
-			}
 
-			if config.Archive == "" {
-				config.Archive = arg
-				i++
-				continue
-			}
+	opts := getopt.New("zip")
+	opts.Bool(&config.Help, 'h', "help")
+	opts.Bool(&config.Version, 'v', "version")
+	opts.Bool(&config.Delete, 'd', "")
+	opts.Bool(&config.Update, 'u', "update")
+	args := opts.Parse(os.Args[1:])
 
-			config.Filenames = append(config.Filenames, arg)
-			i++
-		}
+	if len(args) > 0 {
+		config.Archive = args[0]
+		config.Filenames = args[1:]
 	}
 
+	if config.Help || config.Version {
+		return config
+	}
+	if config.Archive == "" || len(config.Filenames) == 0 {
+		opts.Failf("пропущен архив или файлы")
+	}
 	return config
 }
 
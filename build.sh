@@ -1,6 +1,6 @@
 #!/bin/bash
 
-echo "Сборка всех Go утилит..."
+echo "Сборка Go утилит..."
 
 success=0
 failed=0
@@ -16,27 +16,29 @@ else
     NEED_SOURCE=0
 fi
 
-for go_file in *.go; do
-    if [[ -f "$go_file" && "$go_file" != "build.sh" ]]; then
-        echo "Сборка $go_file → ${go_file%.go}"
-        go build -o "${go_file%.go}" "$go_file"
-        if [ $? -eq 0 ]; then
-            echo "Сборка успешна"
-            ((success++))
-        else
-            echo "Ошибка сборки"
-            ((failed++))
-        fi
-    fi
-done
+echo "Сборка lca (все утилиты в одном файле)"
+CGO_ENABLED=0 go build -trimpath -ldflags="-s -w" -o lca ./cmd/lca
+if [ $? -eq 0 ]; then
+    echo "Сборка успешна"
+    ((success++))
+else
+    echo "Ошибка сборки"
+    ((failed++))
+fi
 
-echo ""
-echo "Готово! Исполняемые файлы созданы."
+if [ $failed -eq 0 ]; then
+    echo "Создание ссылок на утилиты в ./bin"
+    ./lca --install bin > /dev/null || ((failed++))
+fi
 
+echo ""
 if [ $failed -ne 0 ]; then
+    echo "Сборка не завершена: исправьте ошибки выше."
     exit 1
 fi
 
+echo "Готово! Исполняемый файл lca и ссылки в ./bin созданы."
+
 if [ "$NEED_SOURCE" -eq 1 ]; then
     echo ""
     echo "Выполните: source ~/.bashrc"
@@ -0,0 +1,161 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/mir-yks/LinuxCommandAnalog/internal/applet"
+
+	_ "github.com/mir-yks/LinuxCommandAnalog/applets/arch"
+	_ "github.com/mir-yks/LinuxCommandAnalog/applets/cat"
+	_ "github.com/mir-yks/LinuxCommandAnalog/applets/cd"
+	_ "github.com/mir-yks/LinuxCommandAnalog/applets/clear"
+	_ "github.com/mir-yks/LinuxCommandAnalog/applets/cp"
+	_ "github.com/mir-yks/LinuxCommandAnalog/applets/date"
+	_ "github.com/mir-yks/LinuxCommandAnalog/applets/df"
+	_ "github.com/mir-yks/LinuxCommandAnalog/applets/du"
+	_ "github.com/mir-yks/LinuxCommandAnalog/applets/exit"
+	_ "github.com/mir-yks/LinuxCommandAnalog/applets/file"
+	_ "github.com/mir-yks/LinuxCommandAnalog/applets/find"
+	_ "github.com/mir-yks/LinuxCommandAnalog/applets/free"
+	_ "github.com/mir-yks/LinuxCommandAnalog/applets/head"
+	_ "github.com/mir-yks/LinuxCommandAnalog/applets/hexdump"
+	_ "github.com/mir-yks/LinuxCommandAnalog/applets/history"
+	_ "github.com/mir-yks/LinuxCommandAnalog/applets/ii"
+	_ "github.com/mir-yks/LinuxCommandAnalog/applets/in"
+	_ "github.com/mir-yks/LinuxCommandAnalog/applets/kill"
+	_ "github.com/mir-yks/LinuxCommandAnalog/applets/ls"
+	_ "github.com/mir-yks/LinuxCommandAnalog/applets/mkdir"
+	_ "github.com/mir-yks/LinuxCommandAnalog/applets/nl"
+	_ "github.com/mir-yks/LinuxCommandAnalog/applets/pgrep"
+	_ "github.com/mir-yks/LinuxCommandAnalog/applets/ps"
+	_ "github.com/mir-yks/LinuxCommandAnalog/applets/pstree"
+	_ "github.com/mir-yks/LinuxCommandAnalog/applets/pwd"
+	_ "github.com/mir-yks/LinuxCommandAnalog/applets/pwgen"
+	_ "github.com/mir-yks/LinuxCommandAnalog/applets/rm"
+	_ "github.com/mir-yks/LinuxCommandAnalog/applets/rmdir"
+	_ "github.com/mir-yks/LinuxCommandAnalog/applets/tail"
+	_ "github.com/mir-yks/LinuxCommandAnalog/applets/tar"
+	_ "github.com/mir-yks/LinuxCommandAnalog/applets/top"
+	_ "github.com/mir-yks/LinuxCommandAnalog/applets/touch"
+	_ "github.com/mir-yks/LinuxCommandAnalog/applets/uname"
+	_ "github.com/mir-yks/LinuxCommandAnalog/applets/unzip"
+	_ "github.com/mir-yks/LinuxCommandAnalog/applets/wc"
+	_ "github.com/mir-yks/LinuxCommandAnalog/applets/zip"
+)
+
+const ver = "1.0.0"
+
+func main() {
+	name := filepath.Base(os.Args[0])
+	if run, ok := applet.Lookup(name); ok {
+		os.Args[0] = name
+		run()
+		return
+	}
+
+	args := os.Args[1:]
+	if len(args) == 0 {
+		printHelp()
+		os.Exit(1)
+	}
+
+	switch args[0] {
+	case "-h", "--help":
+		printHelp()
+		return
+	case "-v", "--version":
+		printVersion()
+		return
+	case "-l", "--list":
+		fmt.Println(strings.Join(applet.Names(), "\n"))
+		return
+	case "--install":
+		if len(args) < 2 {
+			fmt.Fprintln(os.Stderr, "lca: опция --install требует директорию")
+			os.Exit(1)
+		}
+		if err := installLinks(args[1]); err != nil {
+			fmt.Fprintf(os.Stderr, "lca: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	run, ok := applet.Lookup(args[0])
+	if !ok {
+		fmt.Fprintf(os.Stderr, "lca: неизвестная утилита '%s'\n", args[0])
+		fmt.Fprintln(os.Stderr, "По команде «lca --list» можно получить список утилит.")
+		os.Exit(1)
+	}
+
+	os.Args = args
+	run()
+}
+
+// printHelp выводит справку
+func printHelp() {
+	fmt.Println("lca - набор аналогов утилит Linux в одном исполняемом файле")
+	fmt.Println()
+	fmt.Println("Использование: lca УТИЛИТА [АРГУМЕНТЫ]...")
+	fmt.Println("         или: УТИЛИТА [АРГУМЕНТЫ]...  (через символьную ссылку на lca)")
+	fmt.Println()
+	fmt.Println("Опции:")
+	fmt.Println("  -l, --list         показать список утилит")
+	fmt.Println("  --install ДИР      создать в ДИР символьные ссылки на все утилиты")
+	fmt.Println("  -h, --help         показать эту справку")
+	fmt.Println("  -v, --version      показать информацию о версии")
+	fmt.Println()
+	fmt.Println("Утилиты:")
+	fmt.Printf("  %s\n", strings.Join(applet.Names(), " "))
+	fmt.Println()
+	fmt.Println("Примеры:")
+	fmt.Println("  lca ls -l                # Запустить ls")
+	fmt.Println("  lca --install ./bin      # Создать ссылки ls, cat, ... в ./bin")
+}
+
+// printVersion выводит информацию о версии
+func printVersion() {
+	fmt.Println("lca версия", ver)
+	fmt.Println("Разработано в рамках учебного проекта")
+	fmt.Println("Язык программирования: Golang")
+}
+
+// installLinks создает в директории dir символьные ссылки на lca
+// для каждой зарегистрированной утилиты
+func installLinks(dir string) error {
+	exe, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("не удалось определить путь к lca: %v", err)
+	}
+	if exe, err = filepath.EvalSymlinks(exe); err != nil {
+		return fmt.Errorf("не удалось определить путь к lca: %v", err)
+	}
+
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("не удалось создать '%s': %v", dir, err)
+	}
+
+	for _, name := range applet.Names() {
+		link := filepath.Join(dir, name)
+
+		if info, err := os.Lstat(link); err == nil {
+			if info.Mode()&os.ModeSymlink == 0 {
+				fmt.Fprintf(os.Stderr, "lca: '%s' уже существует и не является ссылкой, пропущено\n", link)
+				continue
+			}
+			if err := os.Remove(link); err != nil {
+				return fmt.Errorf("не удалось заменить '%s': %v", link, err)
+			}
+		}
+
+		if err := os.Symlink(exe, link); err != nil {
+			return fmt.Errorf("не удалось создать ссылку '%s': %v", link, err)
+		}
+		fmt.Printf("'%s' -> '%s'\n", link, exe)
+	}
+
+	return nil
+}
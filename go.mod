@@ -0,0 +1,3 @@
+module github.com/mir-yks/LinuxCommandAnalog
+
+go 1.21
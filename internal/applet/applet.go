@@ -0,0 +1,41 @@
+// Package applet хранит реестр утилит, собранных в единый исполняемый файл lca.
+//
+// Каждая утилита регистрирует себя в init() своего пакета, а lca выбирает
+// нужную по имени, под которым был запущен (os.Args[0]), или по первому
+// аргументу командной строки.
+package applet
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Func - точка входа утилиты. Аргументы утилита читает из os.Args,
+// где os.Args[0] уже заменен на ее имя.
+type Func func()
+
+var registry = map[string]Func{}
+
+// Register добавляет утилиту в реестр под указанным именем
+func Register(name string, main Func) {
+	if _, exists := registry[name]; exists {
+		panic(fmt.Sprintf("applet: утилита '%s' зарегистрирована дважды", name))
+	}
+	registry[name] = main
+}
+
+// Lookup возвращает точку входа утилиты по имени
+func Lookup(name string) (Func, bool) {
+	main, ok := registry[name]
+	return main, ok
+}
+
+// Names возвращает отсортированный список зарегистрированных утилит
+func Names() []string {
+	names := make([]string, 0, len(registry))
+	for name := range registry {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,77 @@
+// Package fsmode переводит режим файла Go (fs.FileMode) в представления UNIX:
+// строку прав в стиле ls (drwxr-xr-x) и числовые права с битами
+// setuid, setgid и sticky.
+package fsmode
+
+import (
+	"io/fs"
+)
+
+// String формирует строку прав в виде drwxr-xr-x, как в GNU ls
+func String(mode fs.FileMode) string {
+	buf := []byte("----------")
+	buf[0] = TypeLetter(mode)
+	if buf[0] == 'f' {
+		buf[0] = '-'
+	}
+
+	const rwx = "rwxrwxrwx"
+	for i := 0; i < 9; i++ {
+		if mode&(1<<uint(8-i)) != 0 {
+			buf[i+1] = rwx[i]
+		}
+	}
+
+	// Специальные биты заменяют x; заглавная буква значит, что x не установлен
+	special := func(pos int, set bool, lower, upper byte) {
+		if !set {
+			return
+		}
+		if buf[pos] == 'x' {
+			buf[pos] = lower
+		} else {
+			buf[pos] = upper
+		}
+	}
+	special(3, mode&fs.ModeSetuid != 0, 's', 'S')
+	special(6, mode&fs.ModeSetgid != 0, 's', 'S')
+	special(9, mode&fs.ModeSticky != 0, 't', 'T')
+
+	return string(buf)
+}
+
+// TypeLetter возвращает букву типа файла, как в find -type:
+// f, d, l, c, b, p или s
+func TypeLetter(mode fs.FileMode) byte {
+	switch {
+	case mode&fs.ModeDir != 0:
+		return 'd'
+	case mode&fs.ModeSymlink != 0:
+		return 'l'
+	case mode&fs.ModeCharDevice != 0:
+		return 'c'
+	case mode&fs.ModeDevice != 0:
+		return 'b'
+	case mode&fs.ModeNamedPipe != 0:
+		return 'p'
+	case mode&fs.ModeSocket != 0:
+		return 's'
+	}
+	return 'f'
+}
+
+// Perm возвращает права UNIX вместе с битами setuid (04000), setgid (02000)
+// и sticky (01000)
+func Perm(mode fs.FileMode) uint32 {
+	perm := uint32(mode.Perm())
+	if mode&fs.ModeSetuid != 0 {
+		perm |= 0o4000
+	}
+	if mode&fs.ModeSetgid != 0 {
+		perm |= 0o2000
+	}
+	if mode&fs.ModeSticky != 0 {
+		perm |= 0o1000
+	}
+	return perm
+}
@@ -0,0 +1,309 @@
+// Package getopt разбирает аргументы командной строки в стиле GNU getopt_long.
+//
+// Поддерживаются объединенные короткие ключи (-rv), аргументы, записанные
+// слитно с ключом (-n5) или через пробел (-n 5), длинные ключи в формах
+// --name=value и --name value, однозначные сокращения длинных ключей
+// и маркер конца ключей "--". Ключи и операнды можно перемешивать.
+//
+// При ошибке разбора выводится сообщение вида
+//
+//	ls: неверный ключ — 'x'
+//	По команде «ls --help» можно получить дополнительную информацию.
+//
+// и программа завершается с кодом 2.
+package getopt
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// UsageExitCode - код завершения при ошибке использования
+const UsageExitCode = 2
+
+// ArgMode определяет, принимает ли ключ аргумент
+type ArgMode int
+
+const (
+	NoArgument       ArgMode = iota // ключ без аргумента: -a, --all
+	RequiredArgument                // обязательный аргумент: -n 5, -n5, --lines=5
+	OptionalArgument                // необязательный аргумент, только слитно: -n5, --color=auto
+)
+
+// Option описывает один ключ. Short или Long могут быть пустыми,
+// но не одновременно.
+type Option struct {
+	Short rune
+	Long  string
+	Arg   ArgMode
+	// Set вызывается для каждого вхождения ключа. Для ключа без аргумента
+	// и для необязательного аргумента, который не задан, value пуст.
+	Set func(value string) error
+}
+
+// Set - набор ключей одной утилиты
+type Set struct {
+	prog        string
+	options     []*Option
+	inOrder     bool
+	passUnknown bool
+}
+
+// New создает набор ключей для утилиты prog
+func New(prog string) *Set {
+	return &Set{prog: prog}
+}
+
+// Add добавляет произвольный ключ
+func (s *Set) Add(opt Option) {
+	if opt.Short == 0 && opt.Long == "" {
+		panic("getopt: ключ без имени")
+	}
+	s.options = append(s.options, &opt)
+}
+
+// Bool добавляет ключ-переключатель
+func (s *Set) Bool(p *bool, short rune, long string) {
+	s.Add(Option{Short: short, Long: long, Set: func(string) error {
+		*p = true
+		return nil
+	}})
+}
+
+// Int добавляет ключ с целым аргументом
+func (s *Set) Int(p *int, short rune, long string) {
+	s.Add(Option{Short: short, Long: long, Arg: RequiredArgument, Set: func(v string) error {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return errInvalidValue
+		}
+		*p = n
+		return nil
+	}})
+}
+
+// Int64 добавляет ключ с целым 64-битным аргументом
+func (s *Set) Int64(p *int64, short rune, long string) {
+	s.Add(Option{Short: short, Long: long, Arg: RequiredArgument, Set: func(v string) error {
+		n, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			return errInvalidValue
+		}
+		*p = n
+		return nil
+	}})
+}
+
+// String добавляет ключ со строковым аргументом
+func (s *Set) String(p *string, short rune, long string) {
+	s.Add(Option{Short: short, Long: long, Arg: RequiredArgument, Set: func(v string) error {
+		*p = v
+		return nil
+	}})
+}
+
+// Strings добавляет ключ, значения которого накапливаются при повторении
+func (s *Set) Strings(p *[]string, short rune, long string) {
+	s.Add(Option{Short: short, Long: long, Arg: RequiredArgument, Set: func(v string) error {
+		*p = append(*p, v)
+		return nil
+	}})
+}
+
+// Func добавляет ключ с собственным обработчиком
+func (s *Set) Func(short rune, long string, arg ArgMode, fn func(value string) error) {
+	s.Add(Option{Short: short, Long: long, Arg: arg, Set: fn})
+}
+
+// StopAtOperand прекращает разбор ключей на первом операнде, как
+// POSIXLY_CORRECT. Нужно утилитам, которые передают остаток строки дальше.
+func (s *Set) StopAtOperand() {
+	s.inOrder = true
+}
+
+// PassUnknown прекращает разбор на первом неизвестном ключе и возвращает
+// его вместе с остатком строки как операнды. Нужно утилитам-оберткам,
+// которые передают ключи другой команде.
+func (s *Set) PassUnknown() {
+	s.passUnknown = true
+}
+
+// Parse разбирает args (без имени программы) и возвращает операнды.
+// При ошибке выводит сообщение и завершает программу с кодом 2.
+func (s *Set) Parse(args []string) []string {
+	operands, err := s.parse(args)
+	if err != nil {
+		s.Failf("%v", err)
+	}
+	return operands
+}
+
+// Failf сообщает об ошибке использования и завершает программу с кодом 2
+func (s *Set) Failf(format string, a ...any) {
+	fmt.Fprintf(os.Stderr, "%s: %s\n", s.prog, fmt.Sprintf(format, a...))
+	fmt.Fprintf(os.Stderr, "По команде «%s --help» можно получить дополнительную информацию.\n", s.prog)
+	os.Exit(UsageExitCode)
+}
+
+// errInvalidValue возвращается обработчиком, когда значение нельзя разобрать;
+// Parse дополняет его именем ключа
+var errInvalidValue = fmt.Errorf("неверное значение")
+
+func (s *Set) parse(args []string) ([]string, error) {
+	var operands []string
+
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+
+		if s.passUnknown && s.isUnknown(arg) {
+			return append(operands, args[i:]...), nil
+		}
+
+		switch {
+		case arg == "--":
+			return append(operands, args[i+1:]...), nil
+		case strings.HasPrefix(arg, "--"):
+			next, err := s.parseLong(arg[2:], args, i)
+			if err != nil {
+				return nil, err
+			}
+			i = next
+		case len(arg) > 1 && arg[0] == '-':
+			next, err := s.parseShort(arg[1:], args, i)
+			if err != nil {
+				return nil, err
+			}
+			i = next
+		default:
+			if s.inOrder {
+				return append(operands, args[i:]...), nil
+			}
+			operands = append(operands, arg)
+		}
+	}
+
+	return operands, nil
+}
+
+// parseLong обрабатывает --name[=value]; возвращает индекс последнего
+// использованного аргумента
+func (s *Set) parseLong(body string, args []string, i int) (int, error) {
+	name, value, hasValue := strings.Cut(body, "=")
+
+	opt, err := s.lookupLong(name)
+	if err != nil {
+		return i, err
+	}
+	display := "--" + opt.Long
+
+	switch opt.Arg {
+	case NoArgument:
+		if hasValue {
+			return i, fmt.Errorf("ключ '%s' не допускает аргумент", display)
+		}
+	case RequiredArgument:
+		if !hasValue {
+			if i+1 >= len(args) {
+				return i, fmt.Errorf("ключ '%s' требует аргумент", display)
+			}
+			i++
+			value = args[i]
+		}
+	}
+
+	return i, apply(opt, display, value)
+}
+
+// parseShort обрабатывает группу коротких ключей -abc или -n5
+func (s *Set) parseShort(group string, args []string, i int) (int, error) {
+	for pos, ch := range group {
+		opt := s.lookupShort(ch)
+		if opt == nil {
+			return i, fmt.Errorf("неверный ключ — '%c'", ch)
+		}
+		display := "-" + string(ch)
+		rest := group[pos+len(string(ch)):]
+
+		switch opt.Arg {
+		case NoArgument:
+			if err := apply(opt, display, ""); err != nil {
+				return i, err
+			}
+			continue
+		case RequiredArgument:
+			if rest == "" {
+				if i+1 >= len(args) {
+					return i, fmt.Errorf("ключ требует аргумент — '%c'", ch)
+				}
+				i++
+				rest = args[i]
+			}
+		}
+		return i, apply(opt, display, rest)
+	}
+	return i, nil
+}
+
+// isUnknown сообщает, что arg похож на ключ, но не совпадает ни с одним
+// из известных
+func (s *Set) isUnknown(arg string) bool {
+	switch {
+	case arg == "--" || len(arg) < 2 || arg[0] != '-':
+		return false
+	case strings.HasPrefix(arg, "--"):
+		name, _, _ := strings.Cut(arg[2:], "=")
+		_, err := s.lookupLong(name)
+		return err != nil
+	default:
+		ch, _ := utf8.DecodeRuneInString(arg[1:])
+		return s.lookupShort(ch) == nil
+	}
+}
+
+func (s *Set) lookupShort(ch rune) *Option {
+	for _, opt := range s.options {
+		if opt.Short == ch {
+			return opt
+		}
+	}
+	return nil
+}
+
+// lookupLong ищет длинный ключ по полному имени или однозначному префиксу
+func (s *Set) lookupLong(name string) (*Option, error) {
+	var candidates []*Option
+	for _, opt := range s.options {
+		if opt.Long == "" {
+			continue
+		}
+		if opt.Long == name {
+			return opt, nil
+		}
+		if name != "" && strings.HasPrefix(opt.Long, name) {
+			candidates = append(candidates, opt)
+		}
+	}
+
+	switch len(candidates) {
+	case 0:
+		return nil, fmt.Errorf("нераспознанный ключ '--%s'", name)
+	case 1:
+		return candidates[0], nil
+	default:
+		return nil, fmt.Errorf("ключ '--%s' неоднозначен", name)
+	}
+}
+
+func apply(opt *Option, display, value string) error {
+	err := opt.Set(value)
+	if err == nil {
+		return nil
+	}
+	if err == errInvalidValue {
+		return fmt.Errorf("неверное значение '%s' для ключа %s", value, display)
+	}
+	return fmt.Errorf("%s: %v", display, err)
+}
@@ -0,0 +1,49 @@
+// Package human форматирует размеры в удобном для чтения виде,
+// как ключи -h и --si в ls, du, df и free.
+package human
+
+import (
+	"fmt"
+	"math"
+)
+
+const suffixes = "KMGTPE"
+
+// Size форматирует число байт по степеням 1024: 999, 1.0K, 15K, 2.3G.
+// Дробная часть выводится только для значений меньше 10; округление
+// идет вверх, чтобы размер никогда не занижался.
+func Size(n uint64) string {
+	return format(n, 1024)
+}
+
+// SizeSI форматирует число байт по степеням 1000 (ключи --si и -H)
+func SizeSI(n uint64) string {
+	return format(n, 1000)
+}
+
+func format(n uint64, base float64) string {
+	value := float64(n)
+	if value < base {
+		return fmt.Sprintf("%d", n)
+	}
+
+	unit := -1
+	for value >= base && unit < len(suffixes)-1 {
+		value /= base
+		unit++
+	}
+
+	if value < 10 {
+		value = math.Ceil(value*10) / 10
+		if value < 10 {
+			return fmt.Sprintf("%.1f%c", value, suffixes[unit])
+		}
+	}
+
+	value = math.Ceil(value)
+	if value >= base && unit < len(suffixes)-1 {
+		// После округления вверх значение перешло в следующую единицу: 1024K -> 1.0M
+		return fmt.Sprintf("1.0%c", suffixes[unit+1])
+	}
+	return fmt.Sprintf("%.0f%c", value, suffixes[unit])
+}
@@ -0,0 +1,91 @@
+// Package input открывает входные данные текстовых фильтров.
+//
+// Имя "-" обозначает стандартный ввод; если утилите не передано ни одного
+// файла, она читает стандартный ввод, как это делают cat, head, wc и другие.
+// Файл и поток возвращаются одним типом, поэтому код фильтра не различает их,
+// пока ему не понадобится позиционирование (см. File.Seekable).
+package input
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+)
+
+// Stdin - имя операнда, обозначающее стандартный ввод
+const Stdin = "-"
+
+// File - открытый источник данных: обычный файл или стандартный ввод
+type File struct {
+	*os.File
+	// Name - имя операнда, как его указал пользователь ("-" для стандартного ввода)
+	Name string
+}
+
+// Operands возвращает список входов утилиты: переданные операнды или,
+// если их нет, один стандартный ввод
+func Operands(args []string) []string {
+	if len(args) == 0 {
+		return []string{Stdin}
+	}
+	return args
+}
+
+// Open открывает файл по имени; "-" открывает стандартный ввод
+func Open(name string) (*File, error) {
+	if name == Stdin {
+		return &File{File: os.Stdin, Name: name}, nil
+	}
+
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	return &File{File: f, Name: name}, nil
+}
+
+// IsStdin сообщает, что источник - стандартный ввод
+func (f *File) IsStdin() bool {
+	return f.File == os.Stdin
+}
+
+// Seekable сообщает, что источник - обычный файл, по которому можно
+// перемещаться и чей размер известен заранее. Стандартный ввод тоже может
+// быть таким, если он перенаправлен из файла.
+func (f *File) Seekable() bool {
+	info, err := f.Stat()
+	return err == nil && info.Mode().IsRegular()
+}
+
+// Close закрывает файл; стандартный ввод остается открытым, чтобы его
+// можно было указать несколько раз ("cat - file -")
+func (f *File) Close() error {
+	if f.IsStdin() {
+		return nil
+	}
+	return f.File.Close()
+}
+
+// Describe убирает из ошибки имя операции и файла: утилиты сами называют
+// файл в сообщении, и "open a.txt: no such file or directory" превращается
+// в "no such file or directory"
+func Describe(err error) error {
+	var pathErr *fs.PathError
+	if errors.As(err, &pathErr) {
+		return pathErr.Err
+	}
+	return err
+}
+
+// OpenError и ReadError - общий вид ошибок у утилит, читающих входы через
+// этот пакет: "не удалось открыть 'a': no such file or directory",
+// "ошибка чтения '/tmp': is a directory"
+func OpenError(name string, err error) error {
+	return fmt.Errorf("не удалось открыть '%s': %v", name, Describe(err))
+}
+
+// ReadError - см. OpenError
+func ReadError(name string, err error) error {
+	return fmt.Errorf("ошибка чтения '%s': %v", name, Describe(err))
+}
@@ -0,0 +1,385 @@
+// Package proc читает сведения о процессах из /proc: общий сканер для
+// ps и других утилит, работающих с процессами.
+package proc
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Process - снимок одного процесса
+type Process struct {
+	PID      int
+	PPID     int
+	PGID     int // группа процессов
+	SID      int // сеанс
+	TPGID    int // группа процессов переднего плана терминала
+	Name     string
+	State    byte // R, S, D, Z, T, I...
+	TTY      uint64
+	UID      int    // действующий пользователь
+	UTime    uint64 // такты процессора в режиме пользователя
+	STime    uint64 // такты в режиме ядра
+	Priority int    // приоритет планировщика; у процессов реального времени отрицательный
+	Nice     int
+	Threads  int
+	Start    uint64 // момент запуска в тактах от загрузки системы
+	VSize    uint64 // виртуальная память в байтах
+	RSS      uint64 // резидентная память в байтах
+	Locked   bool   // есть заблокированные в памяти страницы
+	Cmdline  []string
+}
+
+// System - общие сведения, нужные для расчета процентов и времени
+type System struct {
+	Uptime   float64 // секунды с загрузки
+	BootTime time.Time
+	MemTotal uint64 // байты
+	HZ       uint64 // тактов в секунду
+}
+
+// List читает все процессы в порядке PID. Процессы, завершившиеся во время чтения,
+// пропускаются.
+func List() ([]*Process, error) {
+	entries, err := os.ReadDir("/proc")
+	if err != nil {
+		return nil, fmt.Errorf("не удалось прочитать /proc: %v", err)
+	}
+
+	var procs []*Process
+	for _, entry := range entries {
+		pid, err := strconv.Atoi(entry.Name())
+		if err != nil || !entry.IsDir() {
+			continue
+		}
+		if p, err := Read(pid); err == nil {
+			procs = append(procs, p)
+		}
+	}
+	sort.Slice(procs, func(i, j int) bool { return procs[i].PID < procs[j].PID })
+	return procs, nil
+}
+
+// Read читает процесс по PID
+func Read(pid int) (*Process, error) {
+	dir := "/proc/" + strconv.Itoa(pid) + "/"
+	stat, err := os.ReadFile(dir + "stat")
+	if err != nil {
+		return nil, err
+	}
+
+	p := &Process{PID: pid}
+	if err := p.parseStat(stat); err != nil {
+		return nil, err
+	}
+	if status, err := os.ReadFile(dir + "status"); err == nil {
+		p.parseStatus(status)
+	}
+	if cmdline, err := os.ReadFile(dir + "cmdline"); err == nil && len(cmdline) > 0 {
+		p.Cmdline = strings.Split(strings.TrimRight(string(cmdline), "\x00"), "\x00")
+	}
+	return p, nil
+}
+
+// parseStat разбирает /proc/PID/stat. Имя процесса стоит в скобках и может
+// содержать пробелы и скобки, поэтому оно берется до последней ')', а
+// остальные поля отсчитываются после нее.
+func (p *Process) parseStat(data []byte) error {
+	open := bytes.IndexByte(data, '(')
+	end := bytes.LastIndexByte(data, ')')
+	if open < 0 || end < open {
+		return fmt.Errorf("неверный формат stat процесса %d", p.PID)
+	}
+	p.Name = string(data[open+1 : end])
+
+	// fields[0] - третье поле stat (state)
+	fields := strings.Fields(string(data[end+1:]))
+	if len(fields) < 22 {
+		return fmt.Errorf("неверный формат stat процесса %d", p.PID)
+	}
+	field := func(n int) uint64 {
+		v, _ := strconv.ParseUint(fields[n-3], 10, 64)
+		return v
+	}
+	signed := func(n int) int {
+		v, _ := strconv.Atoi(fields[n-3])
+		return v
+	}
+
+	p.State = fields[0][0]
+	p.PPID = signed(4)
+	p.PGID = signed(5)
+	p.SID = signed(6)
+	p.TTY = field(7)
+	p.TPGID = signed(8)
+	p.UTime = field(14)
+	p.STime = field(15)
+	p.Priority = signed(18)
+	p.Nice = signed(19)
+	p.Threads = signed(20)
+	p.Start = field(22)
+	p.VSize = field(23)
+	p.RSS = field(24) * uint64(os.Getpagesize())
+	return nil
+}
+
+// parseStatus берет из /proc/PID/status действующий UID и VmLck
+func (p *Process) parseStatus(data []byte) {
+	for _, line := range strings.Split(string(data), "\n") {
+		name, value, _ := strings.Cut(line, ":")
+		fields := strings.Fields(value)
+		switch {
+		case name == "Uid" && len(fields) > 1:
+			p.UID, _ = strconv.Atoi(fields[1])
+		case name == "VmLck" && len(fields) > 0:
+			p.Locked = fields[0] != "0"
+		}
+	}
+}
+
+// Command возвращает командную строку; у потоков ядра ее нет, и, как
+// в procps, выводится имя в квадратных скобках
+func (p *Process) Command() string {
+	if len(p.Cmdline) == 0 {
+		return "[" + p.Name + "]"
+	}
+	return strings.Join(p.Cmdline, " ")
+}
+
+// Printable заменяет управляющие символы на '?', как procps: перевод
+// строки в имени или аргументах не должен ломать таблицу или дерево
+func Printable(s string) string {
+	return strings.Map(func(r rune) rune {
+		if r < ' ' || r == 0x7f {
+			return '?'
+		}
+		return r
+	}, s)
+}
+
+// SplitList разбивает список из ключа через запятую или пробел, как
+// procps: -p 1,2 -C "bash sshd"
+func SplitList(value string) []string {
+	return strings.FieldsFunc(value, func(r rune) bool { return r == ',' || r == ' ' })
+}
+
+// CPUTime возвращает суммарное процессорное время
+func (p *Process) CPUTime(sys *System) time.Duration {
+	return ticks(p.UTime+p.STime, sys.HZ)
+}
+
+// StartTime возвращает момент запуска процесса
+func (p *Process) StartTime(sys *System) time.Time {
+	return sys.BootTime.Add(ticks(p.Start, sys.HZ))
+}
+
+// Elapsed возвращает время работы процесса
+func (p *Process) Elapsed(sys *System) time.Duration {
+	age := sys.Uptime - float64(p.Start)/float64(sys.HZ)
+	return time.Duration(max(age, 0) * float64(time.Second))
+}
+
+// CPUPercent считает загрузку процессора, как procps: процессорное время,
+// деленное на время жизни процесса
+func (p *Process) CPUPercent(sys *System) float64 {
+	age := p.Elapsed(sys).Seconds()
+	if age <= 0 {
+		return 0
+	}
+	return p.CPUTime(sys).Seconds() * 100 / age
+}
+
+// MemPercent возвращает долю резидентной памяти от MemTotal
+func (p *Process) MemPercent(sys *System) float64 {
+	if sys.MemTotal == 0 {
+		return 0
+	}
+	return float64(p.RSS) * 100 / float64(sys.MemTotal)
+}
+
+// StatString возвращает состояние с флагами procps: < - высокий приоритет,
+// N - низкий, L - заблокированные страницы, s - лидер сеанса,
+// l - многопоточный, + - группа переднего плана
+func (p *Process) StatString() string {
+	s := string(p.State)
+	switch {
+	case p.Nice < 0:
+		s += "<"
+	case p.Nice > 0:
+		s += "N"
+	}
+	if p.Locked {
+		s += "L"
+	}
+	if p.SID == p.PID {
+		s += "s"
+	}
+	if p.Threads > 1 {
+		s += "l"
+	}
+	if p.TPGID == p.PGID && p.TTY != 0 {
+		s += "+"
+	}
+	return s
+}
+
+func ticks(n, hz uint64) time.Duration {
+	return time.Duration(n) * time.Second / time.Duration(hz)
+}
+
+// ReadSystem читает время работы, момент загрузки и объем памяти
+func ReadSystem() (*System, error) {
+	sys := &System{HZ: clockTicks()}
+
+	data, err := os.ReadFile("/proc/uptime")
+	if err != nil {
+		return nil, fmt.Errorf("не удалось прочитать /proc/uptime: %v", err)
+	}
+	if fields := strings.Fields(string(data)); len(fields) > 0 {
+		sys.Uptime, _ = strconv.ParseFloat(fields[0], 64)
+	}
+
+	// Момент загрузки из btime в /proc/stat; это целые секунды, поэтому
+	// при его отсутствии вычисляем по uptime
+	sys.BootTime = time.Now().Add(-time.Duration(sys.Uptime * float64(time.Second)))
+	if data, err := os.ReadFile("/proc/stat"); err == nil {
+		for _, line := range strings.Split(string(data), "\n") {
+			if value, ok := strings.CutPrefix(line, "btime "); ok {
+				if btime, err := strconv.ParseInt(strings.TrimSpace(value), 10, 64); err == nil {
+					sys.BootTime = time.Unix(btime, 0)
+				}
+				break
+			}
+		}
+	}
+
+	if mem, err := MemInfo(); err == nil {
+		sys.MemTotal = mem["MemTotal"]
+	}
+	return sys, nil
+}
+
+// MemInfo читает /proc/meminfo; значения с подписью "kB" переводятся в байты
+func MemInfo() (map[string]uint64, error) {
+	data, err := os.ReadFile("/proc/meminfo")
+	if err != nil {
+		return nil, fmt.Errorf("не удалось прочитать /proc/meminfo: %v", err)
+	}
+	values := map[string]uint64{}
+	for _, line := range strings.Split(string(data), "\n") {
+		name, rest, found := strings.Cut(line, ":")
+		fields := strings.Fields(rest)
+		if !found || len(fields) == 0 {
+			continue
+		}
+		n, err := strconv.ParseUint(fields[0], 10, 64)
+		if err != nil {
+			continue
+		}
+		if len(fields) > 1 && fields[1] == "kB" {
+			n *= 1024
+		}
+		values[name] = n
+	}
+	return values, nil
+}
+
+var (
+	hzOnce sync.Once
+	hz     uint64
+)
+
+// clockTicks возвращает число тактов в секунду (sysconf(_SC_CLK_TCK)).
+// Без cgo оно берется из вспомогательного вектора AT_CLKTCK; почти везде
+// это 100.
+func clockTicks() uint64 {
+	hzOnce.Do(func() {
+		hz = 100
+		data, err := os.ReadFile("/proc/self/auxv")
+		if err != nil {
+			return
+		}
+		const atClkTck = 17
+		// Пары (тип, значение) размером в машинное слово
+		word := strconv.IntSize / 8
+		for i := 0; i+2*word <= len(data); i += 2 * word {
+			var key, value uint64
+			if word == 8 {
+				key = binary.NativeEndian.Uint64(data[i:])
+				value = binary.NativeEndian.Uint64(data[i+8:])
+			} else {
+				key = uint64(binary.NativeEndian.Uint32(data[i:]))
+				value = uint64(binary.NativeEndian.Uint32(data[i+4:]))
+			}
+			if key == atClkTck && value > 0 {
+				hz = value
+				return
+			}
+		}
+	})
+	return hz
+}
+
+// TTYName возвращает имя терминала по номеру устройства из stat:
+// pts/3, tty1, ttyS0; "?" - если терминала нет или он неизвестен
+func TTYName(nr uint64) string {
+	if nr == 0 {
+		return "?"
+	}
+	major := (nr >> 8) & 0xfff
+	minor := (nr & 0xff) | ((nr >> 12) & 0xfff00)
+
+	switch {
+	case major >= 136 && major <= 143:
+		return "pts/" + strconv.FormatUint((major-136)*256+minor, 10)
+	case major == 4 && minor < 64:
+		return "tty" + strconv.FormatUint(minor, 10)
+	case major == 4:
+		return "ttyS" + strconv.FormatUint(minor-64, 10)
+	case major == 5 && minor == 0:
+		return "tty"
+	case major == 5 && minor == 1:
+		return "console"
+	}
+	if name := driverTTY(major, minor); name != "" {
+		return name
+	}
+	return "?"
+}
+
+// driverTTY ищет терминал в /proc/tty/drivers: строки вида
+// "serial /dev/ttyS 4 64-111 serial"
+func driverTTY(major, minor uint64) string {
+	data, err := os.ReadFile("/proc/tty/drivers")
+	if err != nil {
+		return ""
+	}
+	for _, line := range strings.Split(string(data), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 4 || fields[2] != strconv.FormatUint(major, 10) {
+			continue
+		}
+		low, high, isRange := strings.Cut(fields[3], "-")
+		first, _ := strconv.ParseUint(low, 10, 64)
+		last := first
+		if isRange {
+			last, _ = strconv.ParseUint(high, 10, 64)
+		}
+		if minor < first || minor > last {
+			continue
+		}
+		name := strings.TrimPrefix(fields[1], "/dev/")
+		if !isRange {
+			return name
+		}
+		return name + strconv.FormatUint(minor-first, 10)
+	}
+	return ""
+}
@@ -0,0 +1,51 @@
+package proc
+
+import (
+	"os"
+	"sort"
+	"strconv"
+)
+
+// Children группирует процессы по родителю. Порядок детей сохраняется
+// таким же, как в procs.
+func Children(procs []*Process) map[int][]*Process {
+	children := map[int][]*Process{}
+	for _, p := range procs {
+		if p.PPID != p.PID {
+			children[p.PPID] = append(children[p.PPID], p)
+		}
+	}
+	return children
+}
+
+// Roots возвращает процессы, родителей которых нет среди procs: корни
+// деревьев при выводе иерархии
+func Roots(procs []*Process) []*Process {
+	present := make(map[int]bool, len(procs))
+	for _, p := range procs {
+		present[p.PID] = true
+	}
+	var roots []*Process
+	for _, p := range procs {
+		if !present[p.PPID] || p.PPID == p.PID {
+			roots = append(roots, p)
+		}
+	}
+	return roots
+}
+
+// ThreadIDs возвращает номера потоков процесса, кроме главного
+func ThreadIDs(pid int) []int {
+	entries, err := os.ReadDir("/proc/" + strconv.Itoa(pid) + "/task")
+	if err != nil {
+		return nil
+	}
+	var tids []int
+	for _, entry := range entries {
+		if tid, err := strconv.Atoi(entry.Name()); err == nil && tid != pid {
+			tids = append(tids, tid)
+		}
+	}
+	sort.Ints(tids)
+	return tids
+}
@@ -0,0 +1,41 @@
+package proc
+
+import (
+	"fmt"
+	"os/user"
+	"strconv"
+	"sync"
+)
+
+var (
+	userMu    sync.Mutex
+	userNames = map[int]string{}
+)
+
+// UserName возвращает имя пользователя по uid; если имени нет, выводится
+// число. Имена запоминаются: таблица процессов спрашивает их много раз.
+func UserName(uid int) string {
+	userMu.Lock()
+	defer userMu.Unlock()
+
+	if name, ok := userNames[uid]; ok {
+		return name
+	}
+	name := strconv.Itoa(uid)
+	if u, err := user.LookupId(name); err == nil {
+		name = u.Username
+	}
+	userNames[uid] = name
+	return name
+}
+
+// LookupUID возвращает uid по имени пользователя или числу
+func LookupUID(name string) (int, error) {
+	if u, err := user.Lookup(name); err == nil {
+		return strconv.Atoi(u.Uid)
+	}
+	if uid, err := strconv.Atoi(name); err == nil && uid >= 0 {
+		return uid, nil
+	}
+	return 0, fmt.Errorf("неизвестный пользователь '%s'", name)
+}
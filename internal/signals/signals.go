@@ -0,0 +1,172 @@
+// Package signals переводит имена сигналов в номера и обратно для kill,
+// pkill и top: HUP, SIGHUP, hup и 1 означают один и тот же сигнал.
+package signals
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"syscall"
+)
+
+// Signal - сигнал и его имя без префикса SIG
+type Signal struct {
+	Number syscall.Signal
+	Name   string
+}
+
+// common - сигналы, которые есть во всех поддерживаемых системах; номера
+// берутся из syscall, поэтому различаются от системы к системе
+var common = []Signal{
+	{syscall.SIGHUP, "HUP"},
+	{syscall.SIGINT, "INT"},
+	{syscall.SIGQUIT, "QUIT"},
+	{syscall.SIGILL, "ILL"},
+	{syscall.SIGTRAP, "TRAP"},
+	{syscall.SIGABRT, "ABRT"},
+	{syscall.SIGBUS, "BUS"},
+	{syscall.SIGFPE, "FPE"},
+	{syscall.SIGKILL, "KILL"},
+	{syscall.SIGUSR1, "USR1"},
+	{syscall.SIGSEGV, "SEGV"},
+	{syscall.SIGUSR2, "USR2"},
+	{syscall.SIGPIPE, "PIPE"},
+	{syscall.SIGALRM, "ALRM"},
+	{syscall.SIGTERM, "TERM"},
+	{syscall.SIGCHLD, "CHLD"},
+	{syscall.SIGCONT, "CONT"},
+	{syscall.SIGSTOP, "STOP"},
+	{syscall.SIGTSTP, "TSTP"},
+	{syscall.SIGTTIN, "TTIN"},
+	{syscall.SIGTTOU, "TTOU"},
+	{syscall.SIGURG, "URG"},
+	{syscall.SIGXCPU, "XCPU"},
+	{syscall.SIGXFSZ, "XFSZ"},
+	{syscall.SIGVTALRM, "VTALRM"},
+	{syscall.SIGPROF, "PROF"},
+	{syscall.SIGWINCH, "WINCH"},
+	{syscall.SIGIO, "IO"},
+	{syscall.SIGSYS, "SYS"},
+}
+
+// table - все сигналы системы по возрастанию номера, сигналы реального
+// времени - в конце; у сигналов с синонимами (IO и POLL) в списке
+// остается основное имя
+var table = buildTable()
+
+func buildTable() []Signal {
+	seen := map[syscall.Signal]bool{}
+	var list []Signal
+	// Системные имена идут первыми: они главнее синонимов из common
+	for _, s := range append(append([]Signal{}, extra...), common...) {
+		if !seen[s.Number] {
+			seen[s.Number] = true
+			list = append(list, s)
+		}
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Number < list[j].Number })
+	if rtMin > 0 {
+		for sig := rtMin; sig <= rtMax; sig++ {
+			list = append(list, Signal{sig, realtimeName(sig)})
+		}
+	}
+	return list
+}
+
+// realtimeName возвращает имя сигнала реального времени, как в util-linux
+// и bash: нижняя половина отсчитывается от RTMIN, верхняя - от RTMAX
+func realtimeName(sig syscall.Signal) string {
+	switch {
+	case sig == rtMin:
+		return "RTMIN"
+	case sig == rtMax:
+		return "RTMAX"
+	case sig <= (rtMin+rtMax)/2:
+		return "RTMIN+" + strconv.Itoa(int(sig-rtMin))
+	}
+	return "RTMAX-" + strconv.Itoa(int(rtMax-sig))
+}
+
+// aliases - другие имена тех же сигналов
+var aliases = map[string]syscall.Signal{
+	"IOT": syscall.SIGABRT,
+	"CLD": syscall.SIGCHLD,
+	"IO":  syscall.SIGIO,
+}
+
+// List возвращает сигналы по возрастанию номера, включая сигналы реального
+// времени от RTMIN до RTMAX
+func List() []Signal {
+	return table
+}
+
+// Name возвращает имя сигнала без SIG: TERM, RTMIN+2, RTMAX-1; пустую строку,
+// если номер неизвестен
+func Name(sig syscall.Signal) string {
+	for _, s := range table {
+		if s.Number == sig {
+			return s.Name
+		}
+	}
+	return ""
+}
+
+// Parse разбирает сигнал: номер (9), имя с префиксом или без (KILL,
+// SIGKILL, kill) или сигнал реального времени (RTMIN+3, RTMAX-1).
+// Номер 0 допустим: такой сигнал только проверяет, что процесс существует.
+func Parse(s string) (syscall.Signal, error) {
+	if n, err := strconv.Atoi(s); err == nil {
+		if n < 0 || (n > 0 && Name(syscall.Signal(n)) == "") {
+			return 0, fmt.Errorf("неизвестный сигнал '%s'", s)
+		}
+		return syscall.Signal(n), nil
+	}
+
+	name := strings.TrimPrefix(strings.ToUpper(s), "SIG")
+	for _, sig := range table {
+		if sig.Name == name {
+			return sig.Number, nil
+		}
+	}
+	if sig, ok := aliases[name]; ok {
+		return sig, nil
+	}
+	if sig, ok := parseRealtime(name); ok {
+		return sig, nil
+	}
+	return 0, fmt.Errorf("неизвестный сигнал '%s'", s)
+}
+
+// parseRealtime разбирает RTMIN, RTMIN+N, RTMAX и RTMAX-N
+func parseRealtime(name string) (syscall.Signal, bool) {
+	if rtMin == 0 {
+		return 0, false
+	}
+	// От RTMIN отсчитывается вверх, от RTMAX - вниз
+	base, direction := rtMin, "+"
+	rest, ok := strings.CutPrefix(name, "RTMIN")
+	if !ok {
+		if rest, ok = strings.CutPrefix(name, "RTMAX"); !ok {
+			return 0, false
+		}
+		base, direction = rtMax, "-"
+	}
+	sig := base
+	if rest != "" {
+		digits, ok := strings.CutPrefix(rest, direction)
+		n, err := strconv.Atoi(digits)
+		if !ok || err != nil || n < 0 {
+			return 0, false
+		}
+		if direction == "+" {
+			sig += syscall.Signal(n)
+		} else {
+			sig -= syscall.Signal(n)
+		}
+	}
+	if sig < rtMin || sig > rtMax {
+		return 0, false
+	}
+	return sig, true
+}
@@ -0,0 +1,17 @@
+package signals
+
+import "syscall"
+
+// extra - сигналы, которые есть только в Linux
+var extra = []Signal{
+	{syscall.SIGSTKFLT, "STKFLT"},
+	{syscall.SIGPOLL, "POLL"},
+	{syscall.SIGPWR, "PWR"},
+}
+
+// Сигналы реального времени: 32 и 33 занимает glibc, поэтому, как и в
+// procps, RTMIN - это 34
+const (
+	rtMin syscall.Signal = 34
+	rtMax syscall.Signal = 64
+)
@@ -0,0 +1,14 @@
+//go:build !linux
+
+package signals
+
+import "syscall"
+
+// extra на других системах пуст
+var extra []Signal
+
+// Сигналов реального времени на других системах нет
+const (
+	rtMin syscall.Signal = 0
+	rtMax syscall.Signal = 0
+)
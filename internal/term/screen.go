@@ -0,0 +1,78 @@
+package term
+
+import (
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+)
+
+// Управляющие последовательности для полноэкранного режима: выделение
+// строк и курсор, который показывают на время ввода ответа
+const (
+	Reverse    = "\033[7m"
+	Reset      = "\033[0m"
+	CursorHide = "\033[?25l"
+	CursorShow = "\033[?25h"
+)
+
+// Альтернативный экран: после выхода терминал показывает то, что было до запуска
+const (
+	altScreenOn  = "\033[?1049h"
+	altScreenOff = "\033[?1049l"
+)
+
+// defaultRows - высота окна, если ее не удалось узнать
+const defaultRows = 24
+
+// Screen - полноэкранный режим интерактивных утилит (du -i, top): ввод
+// в посимвольном режиме, альтернативный экран и скрытый курсор
+type Screen struct {
+	state   *State
+	signals chan os.Signal
+	once    sync.Once
+}
+
+// OpenScreen включает полноэкранный режим. Close возвращает терминал
+// в исходное состояние; то же происходит, если программу завершают
+// сигналом TERM или HUP (kill из другой оболочки, закрытое окно), иначе
+// оболочка осталась бы без эха, без курсора и на альтернативном экране.
+func OpenScreen() (*Screen, error) {
+	state, err := MakeRaw(os.Stdin.Fd())
+	if err != nil {
+		return nil, err
+	}
+	s := &Screen{state: state, signals: make(chan os.Signal, 1)}
+	signal.Notify(s.signals, syscall.SIGTERM, syscall.SIGHUP)
+	go func() {
+		sig, ok := <-s.signals
+		if !ok {
+			return
+		}
+		s.Close()
+		os.Exit(128 + int(sig.(syscall.Signal)))
+	}()
+
+	os.Stdout.WriteString(altScreenOn + CursorHide)
+	return s, nil
+}
+
+// Close выключает полноэкранный режим; повторные вызовы ничего не делают
+func (s *Screen) Close() {
+	s.once.Do(func() {
+		signal.Stop(s.signals)
+		close(s.signals)
+		os.Stdout.WriteString(Reset + CursorShow + altScreenOff)
+		Restore(os.Stdin.Fd(), s.state)
+	})
+}
+
+// ListHeight возвращает число строк окна под список, если reserved строк
+// заняты заголовком и строкой состояния; не меньше одной
+func ListHeight(reserved int) int {
+	_, rows, ok := Size(os.Stdout.Fd())
+	if !ok {
+		rows = defaultRows
+	}
+	return max(1, rows-reserved)
+}
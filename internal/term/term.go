@@ -0,0 +1,24 @@
+// Package term сообщает сведения о терминале: подключен ли к нему
+// дескриптор и какой ширины окно. Нужен утилитам, которые форматируют
+// вывод под экран, как ls в несколько колонок.
+package term
+
+import (
+	"os"
+	"strconv"
+)
+
+// DefaultWidth - ширина, если ее не удалось узнать ни у терминала, ни из COLUMNS
+const DefaultWidth = 80
+
+// StdoutWidth возвращает ширину стандартного вывода: размер окна терминала,
+// иначе значение переменной COLUMNS, иначе DefaultWidth
+func StdoutWidth() int {
+	if width, ok := Width(os.Stdout.Fd()); ok {
+		return width
+	}
+	if columns, err := strconv.Atoi(os.Getenv("COLUMNS")); err == nil && columns > 0 {
+		return columns
+	}
+	return DefaultWidth
+}
@@ -0,0 +1,77 @@
+package term
+
+import (
+	"syscall"
+	"unsafe"
+)
+
+// winsize - структура ioctl TIOCGWINSZ
+type winsize struct {
+	Row    uint16
+	Col    uint16
+	Xpixel uint16
+	Ypixel uint16
+}
+
+// State - сохраненные настройки терминала для Restore
+type State struct {
+	termios syscall.Termios
+}
+
+func ioctl(fd, request uintptr, arg unsafe.Pointer) syscall.Errno {
+	_, _, errno := syscall.Syscall(syscall.SYS_IOCTL, fd, request, uintptr(arg))
+	return errno
+}
+
+// IsTerminal сообщает, что дескриптор подключен к терминалу
+func IsTerminal(fd uintptr) bool {
+	var termios syscall.Termios
+	return ioctl(fd, syscall.TCGETS, unsafe.Pointer(&termios)) == 0
+}
+
+// Width возвращает число колонок окна терминала, подключенного к дескриптору
+func Width(fd uintptr) (int, bool) {
+	cols, _, ok := Size(fd)
+	return cols, ok
+}
+
+// Size возвращает число колонок и строк окна терминала
+func Size(fd uintptr) (cols, rows int, ok bool) {
+	var ws winsize
+	if ioctl(fd, syscall.TIOCGWINSZ, unsafe.Pointer(&ws)) != 0 || ws.Col == 0 {
+		return 0, 0, false
+	}
+	return int(ws.Col), int(ws.Row), true
+}
+
+// MakeRaw переводит ввод терминала в посимвольный режим без эха и без
+// обработки Ctrl+C: клавиши приходят программе сразу, по одному байту.
+// Вывод не меняется, перевод строки по-прежнему возвращает каретку.
+func MakeRaw(fd uintptr) (*State, error) {
+	var state State
+	if errno := ioctl(fd, syscall.TCGETS, unsafe.Pointer(&state.termios)); errno != 0 {
+		return nil, errno
+	}
+
+	raw := state.termios
+	raw.Iflag &^= syscall.IGNBRK | syscall.BRKINT | syscall.PARMRK | syscall.ISTRIP |
+		syscall.INLCR | syscall.IGNCR | syscall.ICRNL | syscall.IXON
+	raw.Lflag &^= syscall.ECHO | syscall.ECHONL | syscall.ICANON | syscall.ISIG | syscall.IEXTEN
+	raw.Cflag &^= syscall.CSIZE | syscall.PARENB
+	raw.Cflag |= syscall.CS8
+	raw.Cc[syscall.VMIN] = 1
+	raw.Cc[syscall.VTIME] = 0
+
+	if errno := ioctl(fd, syscall.TCSETS, unsafe.Pointer(&raw)); errno != 0 {
+		return nil, errno
+	}
+	return &state, nil
+}
+
+// Restore возвращает настройки терминала, сохраненные MakeRaw
+func Restore(fd uintptr, state *State) error {
+	if errno := ioctl(fd, syscall.TCSETS, unsafe.Pointer(&state.termios)); errno != 0 {
+		return errno
+	}
+	return nil
+}
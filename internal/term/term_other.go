@@ -0,0 +1,37 @@
+//go:build !linux
+
+package term
+
+import (
+	"errors"
+)
+
+// State - сохраненные настройки терминала для Restore
+type State struct{}
+
+var errUnsupported = errors.New("управление терминалом не поддерживается на этой системе")
+
+// IsTerminal на других системах не определяется, и вывод считается не терминалом
+func IsTerminal(fd uintptr) bool {
+	return false
+}
+
+// Width на других системах не определяется; используется COLUMNS
+func Width(fd uintptr) (int, bool) {
+	return 0, false
+}
+
+// Size на других системах не определяется
+func Size(fd uintptr) (cols, rows int, ok bool) {
+	return 0, 0, false
+}
+
+// MakeRaw на других системах не поддерживается
+func MakeRaw(fd uintptr) (*State, error) {
+	return nil, errUnsupported
+}
+
+// Restore на других системах ничего не делает
+func Restore(fd uintptr, state *State) error {
+	return errUnsupported
+}
@@ -0,0 +1,362 @@
+// Package walk обходит деревья директорий параллельно.
+//
+// Чтение директорий и lstat их записей - самая медленная часть find и du,
+// особенно на сетевых файловых системах, поэтому этим занимается пул
+// горутин ограниченного размера. Обработчики Enter, Leave и Error при этом
+// всегда вызываются последовательно в горутине, вызвавшей Walk, и им не
+// нужны блокировки.
+//
+// В упорядоченном режиме (Options.Sorted) записи приходят в порядке обхода
+// в глубину с сортировкой по имени, как у filepath.WalkDir: пока
+// обрабатывается одна директория, несколько следующих соседних читаются
+// заранее. В
+// неупорядоченном режиме записи приходят в порядке готовности, что быстрее
+// на больших деревьях; гарантируется лишь, что Enter директории вызывается
+// раньше, чем для ее содержимого, а Leave - позже.
+package walk
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+	"syscall"
+
+	"github.com/mir-yks/LinuxCommandAnalog/internal/input"
+)
+
+// prefetchWindow - сколько соседних директорий упорядоченный обход читает
+// заранее. Окно небольшое: заранее прочитанная директория, в которую Enter
+// потом не пустит, - лишняя работа, а ее содержимое держится в памяти.
+const prefetchWindow = 4
+
+// ErrCycle сообщается для директории, совпадающей с одним из своих предков
+// (например, при повторном монтировании каталога внутрь самого себя)
+var ErrCycle = errors.New("обнаружен цикл в файловой системе")
+
+// Entry - найденный файл
+type Entry struct {
+	Path   string
+	Root   string      // начальная точка, из которой найден файл
+	Info   fs.FileInfo // сведения lstat: символьные ссылки не раскрываются
+	Depth  int         // 0 для начальной точки
+	Parent *Entry      // директория, в которой найден файл; nil для начальной точки
+	Dev    uint64      // устройство файловой системы
+	Ino    uint64
+
+	pending int              // незавершенные дети и собственное чтение (неупорядоченный режим)
+	result  chan dirContents // прочитанное заранее содержимое (упорядоченный режим)
+}
+
+// Options настраивает обход
+type Options struct {
+	// Workers - сколько директорий читается одновременно; 0 - по числу процессоров
+	Workers int
+	// Sorted включает детерминированный порядок обхода
+	Sorted bool
+	// SameFS запрещает спускаться в директории другой файловой системы (find -xdev, du -x)
+	SameFS bool
+	// MaxDepth ограничивает глубину спуска; -1 - без ограничения
+	MaxDepth int
+
+	// Enter вызывается для каждого файла до его содержимого; false запрещает
+	// спуск в директорию. Если Enter не задан, обходится все дерево.
+	Enter func(e *Entry) bool
+	// Leave вызывается для каждого файла после всего его содержимого
+	Leave func(e *Entry)
+	// Error получает ошибки доступа к начальным точкам и директориям
+	Error func(path string, err error)
+}
+
+// dirContents - результат чтения директории
+type dirContents struct {
+	entries []*Entry
+	err     error
+}
+
+type walker struct {
+	opts Options
+	sem  chan struct{}
+}
+
+// Walk обходит деревья с корнями roots
+func Walk(roots []string, opts Options) {
+	if opts.Workers <= 0 {
+		opts.Workers = max(4, 2*runtime.NumCPU())
+	}
+	if opts.Enter == nil {
+		opts.Enter = func(*Entry) bool { return true }
+	}
+	if opts.Leave == nil {
+		opts.Leave = func(*Entry) {}
+	}
+	if opts.Error == nil {
+		opts.Error = func(string, error) {}
+	}
+
+	w := &walker{opts: opts, sem: make(chan struct{}, opts.Workers)}
+	for _, root := range roots {
+		info, err := os.Lstat(root)
+		if err != nil {
+			opts.Error(root, input.Describe(err))
+			continue
+		}
+		e := newEntry(root, root, info, nil)
+		if opts.Sorted {
+			w.walkSorted(e)
+		} else {
+			w.walkUnordered(e)
+		}
+	}
+}
+
+func newEntry(path, root string, info fs.FileInfo, parent *Entry) *Entry {
+	e := &Entry{Path: path, Root: root, Info: info, Parent: parent}
+	if parent != nil {
+		e.Depth = parent.Depth + 1
+	}
+	if st, ok := info.Sys().(*syscall.Stat_t); ok {
+		e.Dev = uint64(st.Dev)
+		e.Ino = uint64(st.Ino)
+	}
+	return e
+}
+
+// canDescend проверяет ограничения глубины, файловой системы и циклы.
+// Ошибка возвращается только для цикла.
+func (w *walker) canDescend(e *Entry) (bool, error) {
+	if !e.Info.IsDir() {
+		return false, nil
+	}
+	if w.opts.MaxDepth >= 0 && e.Depth >= w.opts.MaxDepth {
+		return false, nil
+	}
+	if w.opts.SameFS && e.Parent != nil && e.Dev != rootOf(e).Dev {
+		return false, nil
+	}
+	for p := e.Parent; p != nil; p = p.Parent {
+		if p.Dev == e.Dev && p.Ino == e.Ino && e.Ino != 0 {
+			return false, ErrCycle
+		}
+	}
+	return true, nil
+}
+
+func rootOf(e *Entry) *Entry {
+	for e.Parent != nil {
+		e = e.Parent
+	}
+	return e
+}
+
+// readDir читает директорию и выполняет lstat для всех ее записей
+func (w *walker) readDir(dir *Entry) dirContents {
+	w.sem <- struct{}{}
+	defer func() { <-w.sem }()
+
+	f, err := os.Open(dir.Path)
+	if err != nil {
+		return dirContents{err: input.Describe(err)}
+	}
+	dirEntries, err := f.ReadDir(-1)
+	f.Close()
+
+	prefix := strings.TrimSuffix(dir.Path, "/") + "/"
+	entries := make([]*Entry, 0, len(dirEntries))
+	for _, d := range dirEntries {
+		info, err := d.Info()
+		if err != nil {
+			// Файл удалили во время обхода
+			continue
+		}
+		entries = append(entries, newEntry(prefix+d.Name(), dir.Root, info, dir))
+	}
+
+	if w.opts.Sorted {
+		sort.Slice(entries, func(i, j int) bool {
+			return entries[i].Info.Name() < entries[j].Info.Name()
+		})
+	}
+	if err != nil {
+		err = input.Describe(err)
+	}
+	return dirContents{entries: entries, err: err}
+}
+
+// prefetch начинает чтение директории в фоне; false - если это не
+// директория или спускаться в нее нельзя
+func (w *walker) prefetch(e *Entry) bool {
+	if ok, _ := w.canDescend(e); !ok {
+		return false
+	}
+	e.result = make(chan dirContents, 1)
+	go func() {
+		e.result <- w.readDir(e)
+	}()
+	return true
+}
+
+// walkSorted обходит дерево в глубину. Впереди текущей записи читается
+// не больше prefetchWindow соседних директорий, поэтому память ограничена
+// окном на каждом уровне текущего пути, а не всеми соседями.
+func (w *walker) walkSorted(e *Entry) {
+	descend := w.opts.Enter(e)
+
+	ok, err := w.canDescend(e)
+	if err != nil && descend {
+		w.opts.Error(e.Path, err)
+	}
+	if descend && ok {
+		if e.result == nil {
+			w.prefetch(e)
+		}
+		contents := <-e.result
+		if contents.err != nil {
+			w.opts.Error(e.Path, contents.err)
+		}
+		children := contents.entries
+		next, ahead := 0, 0
+		for _, child := range children {
+			for next < len(children) && ahead < prefetchWindow {
+				if w.prefetch(children[next]) {
+					ahead++
+				}
+				next++
+			}
+			if child.result != nil {
+				ahead--
+			}
+			w.walkSorted(child)
+		}
+	} else if e.result != nil {
+		// Директорию прочитали заранее, но спуск запрещен: дожидаемся
+		// горутины, чтобы она не осталась висеть
+		<-e.result
+	}
+	e.result = nil
+
+	w.opts.Leave(e)
+}
+
+// unordered - состояние неупорядоченного обхода одного дерева
+type unordered struct {
+	*walker
+	mu      sync.Mutex
+	cond    *sync.Cond
+	queue   []*Entry
+	done    bool
+	results chan dirResult
+}
+
+type dirResult struct {
+	dir *Entry
+	dirContents
+}
+
+// walkUnordered обходит дерево пулом горутин; результаты обрабатываются
+// по мере готовности
+func (w *walker) walkUnordered(root *Entry) {
+	u := &unordered{walker: w, results: make(chan dirResult, w.opts.Workers)}
+	u.cond = sync.NewCond(&u.mu)
+
+	var wg sync.WaitGroup
+	for i := 0; i < w.opts.Workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			u.work()
+		}()
+	}
+
+	outstanding := 0
+	var visit func(e *Entry)
+	visit = func(e *Entry) {
+		descend := w.opts.Enter(e)
+		ok, err := w.canDescend(e)
+		if err != nil && descend {
+			w.opts.Error(e.Path, err)
+		}
+		if descend && ok {
+			e.pending = 1
+			outstanding++
+			u.submit(e)
+			return
+		}
+		u.finish(e)
+	}
+
+	visit(root)
+	for outstanding > 0 {
+		r := <-u.results
+		outstanding--
+		if r.err != nil {
+			w.opts.Error(r.dir.Path, r.err)
+		}
+		// Собственное чтение директории считается завершенным только после
+		// обработки всех записей, иначе Leave мог бы прийти раньше времени
+		r.dir.pending += len(r.entries)
+		for _, child := range r.entries {
+			visit(child)
+		}
+		r.dir.pending--
+		if r.dir.pending == 0 {
+			u.finish(r.dir)
+		}
+	}
+
+	u.mu.Lock()
+	u.done = true
+	u.mu.Unlock()
+	u.cond.Broadcast()
+	wg.Wait()
+}
+
+// submit ставит директорию в очередь чтения; очередь не ограничена,
+// поэтому главная горутина никогда не ждет рабочих
+func (u *unordered) submit(e *Entry) {
+	u.mu.Lock()
+	u.queue = append(u.queue, e)
+	u.mu.Unlock()
+	u.cond.Signal()
+}
+
+// work читает директории из очереди, пока обход не завершится
+func (u *unordered) work() {
+	for {
+		u.mu.Lock()
+		for len(u.queue) == 0 && !u.done {
+			u.cond.Wait()
+		}
+		if len(u.queue) == 0 {
+			u.mu.Unlock()
+			return
+		}
+		// Последней добавленной директорией: обход ближе к поиску в глубину
+		// и очередь растет медленнее
+		e := u.queue[len(u.queue)-1]
+		u.queue = u.queue[:len(u.queue)-1]
+		u.mu.Unlock()
+
+		u.results <- dirResult{dir: e, dirContents: u.readDir(e)}
+	}
+}
+
+// finish вызывает Leave для файла и, если это был последний незавершенный
+// файл директории, завершает и ее
+func (u *unordered) finish(e *Entry) {
+	for e != nil {
+		u.opts.Leave(e)
+		p := e.Parent
+		if p == nil {
+			return
+		}
+		p.pending--
+		if p.pending > 0 {
+			return
+		}
+		e = p
+	}
+}